@@ -0,0 +1,74 @@
+package appcenter
+
+import (
+	"context"
+	"net/http"
+)
+
+// AppService definition
+type AppService struct {
+	client *Client
+}
+
+// App is an AppCenter application
+type App struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name,omitempty"`
+	OS          string `json:"os"`
+	Platform    string `json:"platform,omitempty"`
+	Origin      string `json:"origin,omitempty"`
+	ReleaseType string `json:"release_type,omitempty"`
+
+	// Permissions are the roles of the API token user on the application (manager, developer,
+	// viewer, tester), not the scope of the token
+	Permissions []string `json:"permissions,omitempty"`
+
+	Owner *AppOwner `json:"owner,omitempty"`
+}
+
+// AppOwner is the user or organization owning an application
+type AppOwner struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name,omitempty"`
+	Type        string `json:"type"`
+}
+
+// Organization is an AppCenter organization
+type Organization struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name,omitempty"`
+	Origin      string `json:"origin,omitempty"`
+}
+
+// Get returns the configured application
+func (s *AppService) Get(ctx context.Context) (*App, error) {
+	var res App
+	if err := s.client.NewAPIRequest(ctx, http.MethodGet, "", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// List returns the applications the API token can access, for all their owners
+func (s *AppService) List(ctx context.Context) ([]App, error) {
+	var res []App
+	if err := s.client.NewRootAPIRequest(ctx, http.MethodGet, "apps", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Organizations returns the organizations the API token user belongs to
+func (s *AppService) Organizations(ctx context.Context) ([]Organization, error) {
+	var res []Organization
+	if err := s.client.NewRootAPIRequest(ctx, http.MethodGet, "orgs", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
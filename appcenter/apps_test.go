@@ -0,0 +1,45 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListings(t *testing.T) {
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apps":
+			fmt.Fprint(w, `[{"name": "app", "os": "iOS", "owner": {"name": "owner", "type": "org"}},
+				{"name": "other", "os": "Android", "owner": {"name": "me", "type": "user"}}]`)
+		case "/orgs":
+			fmt.Fprint(w, `[{"id": "org-id", "name": "owner", "display_name": "Owner"}]`)
+		case "/apps/owner/app/distribution_groups":
+			fmt.Fprint(w, `[{"id": "group-id", "name": "Beta"}, {"id": "public-id", "name": "Public", "is_public": true}]`)
+		default:
+			t.Errorf("Unexpected request to %v", r.URL.Path)
+		}
+	})
+	defer done()
+
+	apps, err := c.Apps.List(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, apps, 2) {
+		assert.Equal(t, "owner", apps[0].Owner.Name)
+		assert.Equal(t, "me", apps[1].Owner.Name)
+	}
+
+	orgs, err := c.Apps.Organizations(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Organization{{ID: "org-id", Name: "owner", DisplayName: "Owner"}}, orgs)
+
+	groups, err := c.Distribute.Groups(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []DistributionGroup{
+		{ID: "group-id", Name: "Beta"},
+		{ID: "public-id", Name: "Public", IsPublic: true},
+	}, groups)
+}
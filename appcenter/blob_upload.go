@@ -0,0 +1,104 @@
+package appcenter
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// blobBlockSize is the size of the blocks of the uploads to Azure blob storage
+var blobBlockSize int64 = 4 << 20
+
+// uploadBlob uploads the source to the Azure blob storage SAS URL, as blocks uploaded in
+// parallel then committed as a block list
+func (c *Client) uploadBlob(ctx context.Context, sasURL string, src Source) error {
+	count := int((src.Size() + blobBlockSize - 1) / blobBlockSize)
+	ids := make([]string, count)
+
+	g, gctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, runtime.NumCPU())
+
+	for i := 0; i < count; i++ {
+		i := i
+		ids[i] = base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("block-%08d", i)))
+
+		sem <- struct{}{}
+		g.Go(func() error {
+			defer func() { <-sem }()
+
+			start := int64(i) * blobBlockSize
+			data := make([]byte, minInt64(blobBlockSize, src.Size()-start))
+			if _, err := src.ReadAt(data, start); err != nil && err != io.EOF {
+				return NewAppCenterError(ChunkingError, err)
+			}
+
+			return retry(gctx, retryAttempts, retryDelay, func() error {
+				return c.putBlob(gctx, sasURL, url.Values{"comp": {"block"}, "blockid": {ids[i]}}, data)
+			})
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	var list bytes.Buffer
+	list.WriteString(`<?xml version="1.0" encoding="utf-8"?><BlockList>`)
+	for _, id := range ids {
+		fmt.Fprintf(&list, "<Latest>%v</Latest>", id)
+	}
+	list.WriteString("</BlockList>")
+
+	return retry(ctx, retryAttempts, retryDelay, func() error {
+		return c.putBlob(ctx, sasURL, url.Values{"comp": {"blocklist"}}, list.Bytes())
+	})
+}
+
+func (c *Client) putBlob(ctx context.Context, sasURL string, params url.Values, data []byte) error {
+	u, err := url.Parse(sasURL)
+	if err != nil {
+		return err
+	}
+
+	q := u.Query()
+	for k, v := range params {
+		q[k] = v
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err := fmt.Errorf("Blob upload failed: %v", resp.Status)
+		if !retryableStatus(resp.StatusCode) {
+			return permanent(err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
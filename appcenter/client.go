@@ -9,7 +9,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
@@ -28,10 +30,24 @@ type Client struct {
 
 	APIKey string
 
+	Apps *AppService
+
 	Upload *UploadService
 
 	Distribute *DistributeService
 
+	Releases *ReleaseService
+
+	Stores *StoreService
+
+	Symbols *SymbolService
+
+	// Logger receives the logs of the services, the global zerolog logger by default
+	Logger zerolog.Logger
+
+	// UI reports the progress of the services, DefaultUI by default
+	UI UI
+
 	Config struct {
 		OwnerName string
 		AppName   string
@@ -48,11 +64,61 @@ func NewClient(APIKey string) *Client {
 	c := &Client{APIKey: APIKey}
 	c.BaseURL = baseURL
 	c.client = httpClient
+	c.Logger = log.Logger
+	c.UI = DefaultUI
+	c.Apps = &AppService{client: c}
 	c.Distribute = &DistributeService{client: c}
+	c.Releases = &ReleaseService{client: c}
+	c.Stores = &StoreService{client: c}
+	c.Symbols = &SymbolService{client: c}
 	c.Upload = &UploadService{client: c}
 	return c
 }
 
+// WithApp returns a client sharing the configuration of this one, for the provided application
+func (c *Client) WithApp(ownerName string, appName string) *Client {
+	n := NewClient(c.APIKey)
+	n.BaseURL = c.BaseURL
+	n.client = c.client
+	n.Logger = c.Logger
+	n.UI = c.UI
+	n.Config.OwnerName = ownerName
+	n.Config.AppName = appName
+	return n
+}
+
+// WithLogger returns a client sharing the configuration of this one, logging to the provided logger
+func (c *Client) WithLogger(l zerolog.Logger) *Client {
+	n := c.WithApp(c.Config.OwnerName, c.Config.AppName)
+	n.Logger = l
+	return n
+}
+
+// WithUI returns a client sharing the configuration of this one, reporting its progress to the
+// provided UI
+func (c *Client) WithUI(ui UI) *Client {
+	n := c.WithApp(c.Config.OwnerName, c.Config.AppName)
+	n.UI = ui
+	return n
+}
+
+type loggerKey struct{}
+
+// withLogger returns a context carrying the logger, so the services log the fields of the
+// operation in progress (ex: the upload ID)
+func withLogger(ctx context.Context, l zerolog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, &l)
+}
+
+// logger returns the logger of the context, or the logger of the client
+func (c *Client) logger(ctx context.Context) *zerolog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*zerolog.Logger); ok {
+		return l
+	}
+
+	return &c.Logger
+}
+
 // Response of request
 type Response struct {
 	*http.Response
@@ -90,7 +156,12 @@ func checkError(r *http.Response) *StatusError {
 
 	errorResponse := &StatusError{}
 	if err := json.NewDecoder(r.Body).Decode(errorResponse); err != nil {
-		return &StatusError{Message: "Failed to decode response body"}
+		return &StatusError{StatusCode: r.StatusCode, Message: "Failed to decode response body"}
+	}
+
+	// the status code is not always part of the body
+	if errorResponse.StatusCode == 0 {
+		errorResponse.StatusCode = r.StatusCode
 	}
 
 	return errorResponse
@@ -112,10 +183,10 @@ func (c *Client) simpleRequest(ctx context.Context, method string, url string, b
 		return nil, err
 	}
 
-	return c.do(req, &responseBody)
+	return c.do(ctx, req, &responseBody)
 }
 
-func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -144,11 +215,11 @@ func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
 				return nil, err
 			}
 
-			log.Debug().Str("Body", string(body)).Msg("Response")
+			c.logger(ctx).Debug().Str("Body", string(body)).Msg("Response")
 
-			err = json.Unmarshal(body, &v)
-			if err == io.EOF {
-				err = nil // ignore EOF errors caused by empty response body
+			// ignore empty response bodies
+			if len(body) > 0 {
+				err = json.Unmarshal(body, &v)
 			}
 		}
 	}
@@ -156,13 +227,32 @@ func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
 	return response, err
 }
 
-// NewAPIRequest is a helper method to do request to AppCenter OpenAPI endpoints
+// NewAPIRequest is a helper method to do request to AppCenter OpenAPI endpoints of the configured
+// application
 func (c *Client) NewAPIRequest(
 	ctx context.Context,
 	method string,
 	path string,
 	requestBody interface{},
 	responseBody interface{},
+) error {
+	return c.NewRootAPIRequest(
+		ctx,
+		method,
+		strings.TrimSuffix(fmt.Sprintf("apps/%s/%s/%s", c.Config.OwnerName, c.Config.AppName, path), "/"),
+		requestBody,
+		responseBody,
+	)
+}
+
+// NewRootAPIRequest is a helper method to do request to AppCenter OpenAPI endpoints, the path
+// being relative to the API base URL
+func (c *Client) NewRootAPIRequest(
+	ctx context.Context,
+	method string,
+	path string,
+	requestBody interface{},
+	responseBody interface{},
 ) error {
 	body := new(bytes.Buffer)
 	if requestBody != nil {
@@ -176,23 +266,19 @@ func (c *Client) NewAPIRequest(
 	req, err := http.NewRequestWithContext(
 		ctx,
 		method,
-		fmt.Sprintf("%s/apps/%s/%s/%s",
-			BaseURL,
-			c.Config.OwnerName,
-			c.Config.AppName,
-			path), body)
+		fmt.Sprintf("%s/%s", c.BaseURL, path), body)
 	if err != nil {
 		return err
 	}
 
 	req.Header.Add("Content-Type", "application/json")
 
-	log.Debug().Str("URL", req.URL.String()).Msg("API Request")
+	c.logger(ctx).Debug().Str("URL", req.URL.String()).Msg("API Request")
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.do(c.applyTokenToRequest(req), &responseBody)
+	resp, err := c.do(ctx, c.applyTokenToRequest(req), &responseBody)
 	if err != nil {
 		return err
 	}
@@ -1,5 +1,13 @@
 package appcenter
 
+import (
+	"goappcenter/inspect"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
 var mapping = map[string]string{
 	"apk":        "application/vnd.android.package-archive",
 	"aab":        "application/vnd.android.package-archive",
@@ -20,12 +28,36 @@ var mapping = map[string]string{
 
 const defaultOctetStream = "application/octet-stream"
 
-// ResolveContentType will resolve the right content type for the provided extension name. If the
-// provided extension is not of a support format, it will return defaultOctetStream as default
+// ResolveContentType will resolve the right content type for the provided extension name, with or
+// without its leading dot. If the provided extension is not of a support format, it will return
+// defaultOctetStream as default
 func ResolveContentType(fileExtension string) string {
-	if val, ok := mapping[fileExtension]; ok {
+	if val, ok := mapping[strings.ToLower(strings.TrimPrefix(fileExtension, "."))]; ok {
 		return val
 	}
 
 	return defaultOctetStream
 }
+
+// DetectContentType resolves the content type of the binary from its signature, so that an AAB
+// renamed as an APK or an IPA renamed as a ZIP are still recognized. The extension is used when
+// the signature is not specific to a format. The mismatches of the extension are logged to l.
+func DetectContentType(src Source, l *zerolog.Logger) string {
+	format, err := inspect.DetectFormatReader(src, src.Size(), src.Name())
+	if err != nil {
+		l.Debug().Err(err).Msg("Failed to detect the file format")
+	}
+
+	if format == "" || format == "zip" {
+		return ResolveContentType(filepath.Ext(src.Name()))
+	}
+
+	if ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(src.Name()), ".")); ext != format {
+		l.Warn().
+			Str("Extension", ext).
+			Str("Format", format).
+			Msg("The file extension does not match its content")
+	}
+
+	return ResolveContentType(format)
+}
@@ -0,0 +1,62 @@
+package appcenter
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveContentType(t *testing.T) {
+	assert.Equal(t, "application/vnd.android.package-archive", ResolveContentType(".apk"))
+	assert.Equal(t, "application/vnd.android.package-archive", ResolveContentType("APK"))
+	assert.Equal(t, "application/x-msix", ResolveContentType("msix"))
+	assert.Equal(t, defaultOctetStream, ResolveContentType(".ipa"))
+	assert.Equal(t, defaultOctetStream, ResolveContentType(""))
+}
+
+func TestDetectContentType(t *testing.T) {
+	dir, err := ioutil.TempDir("", "appcenter")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(name string, entries ...string) Source {
+		path := filepath.Join(dir, name)
+		f, err := os.Create(path)
+		assert.NoError(t, err)
+		defer f.Close()
+
+		w := zip.NewWriter(f)
+		for _, e := range entries {
+			_, err := w.Create(e)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, w.Close())
+
+		src, err := openFileSource(path)
+		assert.NoError(t, err)
+		return src
+	}
+
+	var logs bytes.Buffer
+	l := zerolog.New(&logs)
+
+	// an APK renamed as a zip file
+	assert.Equal(t, "application/vnd.android.package-archive", DetectContentType(write("app.zip", "AndroidManifest.xml"), &l))
+	assert.Contains(t, logs.String(), "The file extension does not match its content")
+
+	// zip based formats without specific entries rely on their extension
+	logs.Reset()
+	assert.Equal(t, "application/x-appxupload", DetectContentType(write("app.appxupload", "app.appx"), &l))
+	assert.Empty(t, logs.String())
+
+	// the content type can be overridden
+	r := UploadTask{ContentType: "application/test"}
+	assert.Equal(t, "application/test", r.contentType(write("app.apk", "AndroidManifest.xml"), &log.Logger))
+}
@@ -4,8 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-
-	"github.com/pterm/pterm"
+	"net/url"
 )
 
 // DistributeService definition
@@ -25,6 +24,12 @@ type distributionBody struct {
 	NotifyTester    bool   `json:"notify_testers"`
 }
 
+type distributionTesterBody struct {
+	Email           string `json:"email"`
+	MandatoryUpdate bool   `json:"mandatory_update"`
+	NotifyTester    bool   `json:"notify_testers"`
+}
+
 type distributionResponse struct {
 	GroupID               string `json:"id"`
 	MandatoryUpdate       bool   `json:"mandatory_update"`
@@ -33,18 +38,86 @@ type distributionResponse struct {
 
 // Do Distribute the designated release into the provided configuration
 func (s *DistributeService) Do(ctx context.Context, releaseID int64, request UploadTask) error {
+	return s.Release(ctx, releaseID, request.Distribute)
+}
+
+// Redistribute resolves an already uploaded release and distributes it to the provided
+// destinations, without uploading the binary again
+func (s *DistributeService) Redistribute(
+	ctx context.Context,
+	q ReleaseQuery,
+	p DistributionPayload,
+) (*ReleaseDetails, error) {
+	release, err := s.client.Releases.Resolve(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return release, s.Release(ctx, release.ID, p)
+}
 
-	if request.Distribute.GroupName != "" {
-		group, err := s.requestGroup(ctx, request.Distribute.GroupName, request.OwnerName, request.AppName)
-		if err != nil {
-			return err
+// Release distributes the designated release to the groups, testers and stores of the payload
+func (s *DistributeService) Release(ctx context.Context, releaseID int64, p DistributionPayload) error {
+	_, err := s.ReleaseResults(ctx, releaseID, p)
+	return err
+}
+
+// ReleaseResults distributes the release like Release, and returns the result of each destination.
+// The distribution stops at the first failing destination, which is the last of the results.
+func (s *DistributeService) ReleaseResults(
+	ctx context.Context,
+	releaseID int64,
+	p DistributionPayload,
+) ([]DistributionResult, error) {
+	res, err := s.releaseResults(ctx, releaseID, p)
+	if err != nil {
+		return res, NewAppCenterError(DistributionError, err)
+	}
+
+	return res, nil
+}
+
+func (s *DistributeService) releaseResults(
+	ctx context.Context,
+	releaseID int64,
+	p DistributionPayload,
+) ([]DistributionResult, error) {
+	res := []DistributionResult{}
+
+	for _, name := range p.GroupNames {
+		r := DistributionResult{Type: DestinationGroup, Name: name}
+
+		group, err := s.requestGroup(ctx, name, s.client.Config.OwnerName, s.client.Config.AppName)
+		if err == nil {
+			r.ID = group.ID
+			err = s.releaseToGroup(ctx, releaseID, group.ID, p)
 		}
 
-		err = s.releaseToGroup(ctx, request.OwnerName, request.AppName, releaseID, group.ID)
-		return err
+		if res = append(res, r.failed(err)); err != nil {
+			return res, err
+		}
 	}
 
-	return nil
+	for _, email := range p.Testers {
+		err := s.releaseToTester(ctx, releaseID, email, p)
+
+		r := DistributionResult{Type: DestinationTester, Name: email}
+		if res = append(res, r.failed(err)); err != nil {
+			return res, err
+		}
+	}
+
+	for _, name := range p.StoreNames {
+		opts := PublishOptions{Wait: p.WaitForStores, RolloutFraction: p.RolloutFraction}
+		err := s.client.Stores.Publish(ctx, releaseID, name, opts)
+
+		r := DistributionResult{Type: DestinationStore, Name: name}
+		if res = append(res, r.failed(err)); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
 }
 
 func (s *DistributeService) requestGroup(
@@ -55,7 +128,7 @@ func (s *DistributeService) requestGroup(
 ) (*distributionGroupResponse, error) {
 	var res distributionGroupResponse
 
-	sp, err := pterm.DefaultSpinner.Start(fmt.Sprintf("Requesting distribution group ID from name '%v'", groupName))
+	sp, err := s.client.UI.Start(fmt.Sprintf("Requesting distribution group ID from name '%v'", groupName))
 	if err != nil {
 		return &res, err
 	}
@@ -63,7 +136,7 @@ func (s *DistributeService) requestGroup(
 	err = s.client.NewAPIRequest(
 		ctx,
 		http.MethodGet,
-		fmt.Sprintf("distribution_groups/%s", groupName),
+		fmt.Sprintf("distribution_groups/%s", url.PathEscape(groupName)),
 		nil,
 		&res,
 	)
@@ -80,20 +153,19 @@ func (s *DistributeService) requestGroup(
 
 func (s *DistributeService) releaseToGroup(
 	ctx context.Context,
-	ownerName string,
-	appName string,
 	releaseID int64,
-	groupID string) error {
-
-	sp, err := pterm.DefaultSpinner.Start("Releasing to group")
+	groupID string,
+	p DistributionPayload,
+) error {
+	sp, err := s.client.UI.Start("Releasing to group")
 	if err != nil {
 		return err
 	}
 
 	body := distributionBody{
 		ID:              groupID,
-		MandatoryUpdate: false,
-		NotifyTester:    false,
+		MandatoryUpdate: p.MandatoryUpdate,
+		NotifyTester:    p.NotifyTesters,
 	}
 
 	r := distributionResponse{}
@@ -110,3 +182,35 @@ func (s *DistributeService) releaseToGroup(
 
 	return nil
 }
+
+func (s *DistributeService) releaseToTester(
+	ctx context.Context,
+	releaseID int64,
+	email string,
+	p DistributionPayload,
+) error {
+	sp, err := s.client.UI.Start(fmt.Sprintf("Releasing to tester '%v'", email))
+	if err != nil {
+		return err
+	}
+
+	body := distributionTesterBody{
+		Email:           email,
+		MandatoryUpdate: p.MandatoryUpdate,
+		NotifyTester:    p.NotifyTesters,
+	}
+
+	r := distributionResponse{}
+
+	path := fmt.Sprintf("releases/%v/testers", releaseID)
+
+	err = s.client.NewAPIRequest(ctx, http.MethodPost, path, &body, &r)
+	if err != nil {
+		sp.Fail()
+		return err
+	}
+
+	sp.Success()
+
+	return nil
+}
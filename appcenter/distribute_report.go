@@ -0,0 +1,200 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// InvitationPending the member did not accept the invitation yet
+	InvitationPending = "pending"
+
+	// InvitationAccepted the member accepted the invitation
+	InvitationAccepted = "accepted"
+)
+
+// GroupMember is a member of a distribution group
+type GroupMember struct {
+	ID            string `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	DisplayName   string `json:"display_name,omitempty"`
+	Email         string `json:"email,omitempty"`
+	InvitePending bool   `json:"invite_pending"`
+}
+
+// InvitationState returns the state of the member invitation to the group
+func (m GroupMember) InvitationState() string {
+	if m.InvitePending {
+		return InvitationPending
+	}
+
+	return InvitationAccepted
+}
+
+// TesterAccess is the access of a group member to a release
+type TesterAccess struct {
+	GroupMember
+	InvitationState string `json:"invitation_state"`
+	HasAccess       bool   `json:"has_access"`
+}
+
+// ReleaseDownloads are the download counters of a release for a distribution group
+type ReleaseDownloads struct {
+	Unique int64 `json:"unique_count"`
+	Total  int64 `json:"count"`
+}
+
+// GroupAccessReport reports which members of a distribution group have access to a release
+type GroupAccessReport struct {
+	ReleaseID    int64             `json:"release_id"`
+	ShortVersion string            `json:"short_version,omitempty"`
+	Version      string            `json:"version,omitempty"`
+	GroupID      string            `json:"group_id"`
+	GroupName    string            `json:"group_name"`
+	Distributed  bool              `json:"distributed"`
+	Downloads    *ReleaseDownloads `json:"downloads,omitempty"`
+	Testers      []TesterAccess    `json:"testers"`
+}
+
+type releaseCountsBody struct {
+	Releases []releaseCountsRelease `json:"releases"`
+}
+
+type releaseCountsRelease struct {
+	Release           string `json:"release"`
+	DistributionGroup string `json:"distribution_group"`
+}
+
+type releaseCountsResponse struct {
+	Counts []struct {
+		ReleaseID         string `json:"release_id"`
+		DistributionGroup string `json:"distribution_group"`
+		ReleaseDownloads
+	} `json:"counts"`
+}
+
+// DistributionGroup is a distribution group of the application
+type DistributionGroup struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name,omitempty"`
+	Origin      string `json:"origin,omitempty"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// Groups returns the distribution groups of the application
+func (s *DistributeService) Groups(ctx context.Context) ([]DistributionGroup, error) {
+	var res []DistributionGroup
+	err := s.client.NewAPIRequest(ctx, http.MethodGet, "distribution_groups", nil, &res)
+
+	return res, err
+}
+
+// GroupMembers returns the members of the distribution group
+func (s *DistributeService) GroupMembers(ctx context.Context, groupName string) ([]GroupMember, error) {
+	var res []GroupMember
+	err := s.client.NewAPIRequest(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("distribution_groups/%s/members", url.PathEscape(groupName)),
+		nil,
+		&res,
+	)
+
+	return res, err
+}
+
+// GroupAccessReport reports the invitation state of the members of the distribution group, and
+// whether they have access to the release matching the query
+func (s *DistributeService) GroupAccessReport(
+	ctx context.Context,
+	q ReleaseQuery,
+	groupName string,
+) (*GroupAccessReport, error) {
+	release, err := s.client.Releases.Resolve(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.requestGroup(ctx, groupName, s.client.Config.OwnerName, s.client.Config.AppName)
+	if err != nil {
+		return nil, err
+	}
+
+	sp, err := s.client.UI.Start(fmt.Sprintf("Requesting members of group '%v'", groupName))
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.GroupMembers(ctx, groupName)
+	if err != nil {
+		sp.Fail()
+		return nil, err
+	}
+
+	sp.Success(fmt.Sprintf("%d members found in group '%v'", len(members), groupName))
+
+	report := GroupAccessReport{
+		ReleaseID:    release.ID,
+		ShortVersion: release.ShortVersion,
+		Version:      release.Version,
+		GroupID:      group.ID,
+		GroupName:    group.Name,
+		Testers:      []TesterAccess{},
+	}
+
+	for _, d := range release.Destinations {
+		if d.ID == group.ID {
+			report.Distributed = true
+		}
+	}
+
+	for _, m := range members {
+		report.Testers = append(report.Testers, TesterAccess{
+			GroupMember:     m,
+			InvitationState: m.InvitationState(),
+			HasAccess:       report.Distributed && !m.InvitePending,
+		})
+	}
+
+	// download counters are only informative, the report is still relevant without them
+	report.Downloads, err = s.releaseDownloads(ctx, release.ID, group.ID)
+	if err != nil {
+		s.client.logger(ctx).Warn().Err(err).Msg("Failed to request the release download counters")
+	}
+
+	return &report, nil
+}
+
+func (s *DistributeService) releaseDownloads(
+	ctx context.Context,
+	releaseID int64,
+	groupID string,
+) (*ReleaseDownloads, error) {
+	body := releaseCountsBody{
+		Releases: []releaseCountsRelease{
+			{Release: fmt.Sprintf("%v", releaseID), DistributionGroup: groupID},
+		},
+	}
+
+	var res releaseCountsResponse
+	if err := s.client.NewAPIRequest(
+		ctx,
+		http.MethodPost,
+		"analytics/distribution/release_counts",
+		&body,
+		&res,
+	); err != nil {
+		return nil, err
+	}
+
+	for _, c := range res.Counts {
+		if c.DistributionGroup == groupID {
+			return &c.ReleaseDownloads, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,44 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupAccessReport(t *testing.T) {
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apps/owner/app/releases/12":
+			fmt.Fprint(w, `{"id": 12, "short_version": "1.2.3", "version": "45",
+				"destinations": [{"id": "group-id", "name": "Beta"}]}`)
+		case "/apps/owner/app/distribution_groups/Beta":
+			fmt.Fprint(w, `{"id": "group-id", "name": "Beta"}`)
+		case "/apps/owner/app/distribution_groups/Beta/members":
+			fmt.Fprint(w, `[{"email": "a@test.com", "invite_pending": false},
+				{"email": "b@test.com", "invite_pending": true}]`)
+		case "/apps/owner/app/analytics/distribution/release_counts":
+			assert.Equal(t, http.MethodPost, r.Method)
+			fmt.Fprint(w, `{"counts": [{"release_id": "12", "distribution_group": "group-id",
+				"unique_count": 1, "count": 3}]}`)
+		default:
+			t.Errorf("Unexpected request to %v", r.URL.Path)
+		}
+	})
+	defer done()
+
+	report, err := c.Distribute.GroupAccessReport(context.Background(), ReleaseQuery{ID: 12}, "Beta")
+	assert.NoError(t, err)
+	assert.True(t, report.Distributed)
+	assert.Equal(t, &ReleaseDownloads{Unique: 1, Total: 3}, report.Downloads)
+
+	if assert.Len(t, report.Testers, 2) {
+		assert.Equal(t, InvitationAccepted, report.Testers[0].InvitationState)
+		assert.True(t, report.Testers[0].HasAccess)
+		assert.Equal(t, InvitationPending, report.Testers[1].InvitationState)
+		assert.False(t, report.Testers[1].HasAccess)
+	}
+}
@@ -0,0 +1,81 @@
+package appcenter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrorKind classifies the errors by the step of the pipeline that failed
+type ErrorKind int
+
+const (
+	// KindUnknown errors not related to a specific step
+	KindUnknown ErrorKind = iota
+
+	// KindValidation invalid input file, arguments or binary
+	KindValidation
+
+	// KindAuthentication the API token is invalid or not allowed to access the application
+	KindAuthentication
+
+	// KindUpload failed to upload the binary or its symbols
+	KindUpload
+
+	// KindProcessing the uploaded binary was rejected by AppCenter
+	KindProcessing
+
+	// KindDistribution failed to distribute the release
+	KindDistribution
+
+	// KindTimeout timeout while waiting for AppCenter
+	KindTimeout
+)
+
+// errorKinds maps the messages of the AppCenter errors to their kind
+var errorKinds = map[string]ErrorKind{
+	InputFileError:       KindValidation,
+	ValidationError:      KindValidation,
+	ReleaseNotFoundError: KindValidation,
+	UploadRequestError:   KindUpload,
+	MetadataError:        KindUpload,
+	ChunkingError:        KindUpload,
+	CommitError:          KindUpload,
+	SymbolUploadError:    KindUpload,
+	PermissionError:      KindAuthentication,
+	PollingFailed:        KindProcessing,
+	ProcessingError:      KindProcessing,
+	DistributionError:    KindDistribution,
+	StorePublishingError: KindDistribution,
+	PollingError:         KindTimeout,
+	StorePollingError:    KindTimeout,
+}
+
+// KindOf returns the kind of the error. Authentication failures and timeouts take precedence
+// over the step reporting them, otherwise the outermost AppCenter error gives the kind.
+func KindOf(err error) ErrorKind {
+	var se *StatusError
+	if errors.As(err, &se) &&
+		(se.StatusCode == http.StatusUnauthorized || se.StatusCode == http.StatusForbidden) {
+		return KindAuthentication
+	}
+
+	kind := KindUnknown
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if e == context.DeadlineExceeded {
+			return KindTimeout
+		}
+
+		if ae, ok := e.(AppCenterError); ok {
+			k := errorKinds[ae.msg]
+			if k == KindTimeout {
+				return k
+			}
+			if kind == KindUnknown {
+				kind = k
+			}
+		}
+	}
+
+	return kind
+}
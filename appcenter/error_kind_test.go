@@ -0,0 +1,63 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindOf(t *testing.T) {
+	unauthorized := &StatusError{Code: "Unauthorized", StatusCode: http.StatusUnauthorized}
+	notFound := &StatusError{Code: "NotFound", StatusCode: http.StatusNotFound}
+
+	testCases := []struct {
+		name string
+		err  error
+		kind ErrorKind
+	}{
+		{"Unknown error", fmt.Errorf("boom"), KindUnknown},
+		{"Input file", NewAppCenterError(InputFileError, fmt.Errorf("missing")), KindValidation},
+		{"Binary validation", NewAppCenterError(ValidationError, nil), KindValidation},
+		{"Upload request", NewAppCenterError(UploadRequestError, notFound), KindUpload},
+		{"Chunks", NewAppCenterError(ChunkingError, nil), KindUpload},
+		{"Processing", NewAppCenterError(ProcessingError, fmt.Errorf("invalid")), KindProcessing},
+		{"Distribution", NewAppCenterError(DistributionError, notFound), KindDistribution},
+		{"Polling timeout", NewAppCenterError(PollingError, nil), KindTimeout},
+		{"Store timeout", NewAppCenterError(DistributionError, NewAppCenterError(StorePollingError, nil)), KindTimeout},
+		{"Deadline", NewAppCenterError(ChunkingError, context.DeadlineExceeded), KindTimeout},
+		{"Unauthorized", NewAppCenterError(UploadRequestError, unauthorized), KindAuthentication},
+		{"Forbidden", &StatusError{StatusCode: http.StatusForbidden}, KindAuthentication},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.kind, KindOf(tc.err))
+		})
+	}
+}
+
+func TestStatusCodeOfErrors(t *testing.T) {
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"code": "Unauthorized", "message": "Invalid token"}`))
+	})
+	defer done()
+
+	err := c.NewAPIRequest(context.Background(), http.MethodGet, "releases/1", nil, nil)
+	assert.Equal(t, KindAuthentication, KindOf(NewAppCenterError(UploadRequestError, err)))
+}
+
+func TestPollingProcessingError(t *testing.T) {
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/apps/owner/app/uploads/releases/upload-id", r.URL.Path)
+		w.Write([]byte(`{"id": "upload-id", "upload_status": "error", "error_details": "Invalid binary"}`))
+	})
+	defer done()
+
+	_, err := c.Upload.PollForRelease(context.Background(), "upload-id")
+	assert.Equal(t, KindProcessing, KindOf(err))
+	assert.Contains(t, err.Error(), "Invalid binary")
+}
@@ -6,18 +6,46 @@ const (
 	// ChunkingError chunks upload step failed
 	ChunkingError = "Failed to upload chunk"
 
+	// CommitError failed to finish the upload or to commit the release
+	CommitError = "Failed to commit the release"
+
+	// DistributionError failed to distribute the release to a group, tester or store
+	DistributionError = "Distribution failed"
+
 	// InputFileError failed to validate the input file
 	InputFileError = "Input file error"
 
 	// MetadataError failed to apply metadata to the upload request
 	MetadataError = "Apply metadata error"
 
-	// PollingError failure while waiting for the upload to be ready to be published
+	// PermissionError the role of the API token user does not allow to upload releases to the
+	// application
+	PermissionError = "The role of the API token user does not allow to upload releases"
+
+	// PollingError timeout while waiting for the upload to be ready to be published
 	PollingError = "Timeout while waiting for upload to be ready to be published"
 
-	// PollingFailed timeout while waiting for the upload to be ready to be published
+	// PollingFailed failure while waiting for the upload to be ready to be published
 	PollingFailed = "Polling failed"
 
+	// ProcessingError the processing of the uploaded binary failed on AppCenter
+	ProcessingError = "Release processing failed"
+
+	// ReleaseNotFoundError no release is matching the provided query
+	ReleaseNotFoundError = "Release not found"
+
+	// StorePollingError timeout while waiting for the release to be published to a store
+	StorePollingError = "Timeout while waiting for the release to be published to the store"
+
+	// StorePublishingError failed to publish the release to a store
+	StorePublishingError = "Store publishing failed"
+
+	// ValidationError the binary does not match the application it is uploaded to
+	ValidationError = "Binary validation failed"
+
+	// SymbolUploadError failed to upload symbols
+	SymbolUploadError = "Symbol upload failed"
+
 	// UploadRequestError failed to request upload
 	UploadRequestError = "Upload request error"
 )
@@ -36,6 +64,11 @@ func (k AppCenterError) Error() string {
 	return k.msg
 }
 
+// Unwrap returns the underlying error
+func (k AppCenterError) Unwrap() error {
+	return k.err
+}
+
 // NewAppCenterError helper method to create a new AppCenterError
 func NewAppCenterError(msg string, err error) error {
 	return AppCenterError{msg: msg, err: err}
@@ -0,0 +1,92 @@
+package appcenter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// defaultPageSize is the number of items requested per page on listing endpoints
+const defaultPageSize = 100
+
+// pageFormat describes how a listing endpoint is paginated
+type pageFormat struct {
+	// top and skip are the names of the page size and offset parameters
+	top, skip string
+
+	// items is the field of the response holding the items of the page, empty when the response
+	// is the array of items
+	items string
+}
+
+// odataPages is the pagination of most of the listing endpoints
+var odataPages = pageFormat{top: "$top", skip: "$skip"}
+
+// paginate requests the listing endpoint at path page by page using the `$top` and `$skip`
+// parameters and invokes fn for each of the items. Endpoints ignoring those parameters return all
+// their items at once, which is detected to stop the iteration.
+func (c *Client) paginate(
+	ctx context.Context,
+	path string,
+	query url.Values,
+	fn func(item json.RawMessage) error,
+) error {
+	return c.paginatePages(ctx, path, query, odataPages, fn)
+}
+
+// paginatePages is paginate for the endpoints with another page format
+func (c *Client) paginatePages(
+	ctx context.Context,
+	path string,
+	query url.Values,
+	format pageFormat,
+	fn func(item json.RawMessage) error,
+) error {
+	var previous json.RawMessage
+
+	for skip := 0; ; skip += defaultPageSize {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set(format.top, strconv.Itoa(defaultPageSize))
+		q.Set(format.skip, strconv.Itoa(skip))
+
+		var page []json.RawMessage
+		if format.items == "" {
+			if err := c.NewAPIRequest(ctx, http.MethodGet, path+"?"+q.Encode(), nil, &page); err != nil {
+				return err
+			}
+		} else {
+			var res map[string]json.RawMessage
+			if err := c.NewAPIRequest(ctx, http.MethodGet, path+"?"+q.Encode(), nil, &res); err != nil {
+				return err
+			}
+			if items, ok := res[format.items]; ok && string(items) != "null" {
+				if err := json.Unmarshal(items, &page); err != nil {
+					return err
+				}
+			}
+		}
+
+		// the endpoint returned the same page again
+		if len(page) > 0 && bytes.Equal(page[0], previous) {
+			return nil
+		}
+
+		for _, item := range page {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		if len(page) != defaultPageSize {
+			return nil
+		}
+
+		previous = page[0]
+	}
+}
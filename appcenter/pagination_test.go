@@ -0,0 +1,92 @@
+package appcenter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, func()) {
+	server := httptest.NewServer(handler)
+
+	c := NewClient("api-key")
+	c.Config.OwnerName = "owner"
+	c.Config.AppName = "app"
+
+	u, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	c.BaseURL = u
+
+	return c, server.Close
+}
+
+func TestPagination(t *testing.T) {
+	collect := func(c *Client) ([]int, error) {
+		res := []int{}
+		err := c.paginate(context.Background(), "releases", nil, func(item json.RawMessage) error {
+			var i int
+			err := json.Unmarshal(item, &i)
+			res = append(res, i)
+			return err
+		})
+		return res, err
+	}
+
+	t.Run("Pages should be requested till a page is incomplete", func(t *testing.T) {
+		count := 0
+		c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			count++
+			assert.Equal(t, "/apps/owner/app/releases", r.URL.Path)
+			top, _ := strconv.Atoi(r.URL.Query().Get("$top"))
+			skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+
+			page := []int{}
+			for i := skip; i < skip+top && i < 250; i++ {
+				page = append(page, i)
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(page))
+		})
+		defer done()
+
+		res, err := collect(c)
+		assert.NoError(t, err)
+		assert.Len(t, res, 250)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("Endpoints ignoring the pagination should be requested once", func(t *testing.T) {
+		count := 0
+		c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			count++
+			page := []int{}
+			for i := 0; i < defaultPageSize; i++ {
+				page = append(page, i)
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(page))
+		})
+		defer done()
+
+		res, err := collect(c)
+		assert.NoError(t, err)
+		assert.Len(t, res, defaultPageSize)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("Errors should be reported", func(t *testing.T) {
+		c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code": "NotFound", "message": "Not found"}`)
+		})
+		defer done()
+
+		_, err := collect(c)
+		assert.Error(t, err)
+	})
+}
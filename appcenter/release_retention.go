@@ -0,0 +1,97 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RetentionPolicy describes the releases to keep when pruning the releases of an application. A
+// release is kept as soon as one of the rules retains it.
+type RetentionPolicy struct {
+	// KeepLast keeps the N most recent releases
+	KeepLast int
+
+	// KeepNewerThan keeps the releases uploaded less than this duration ago
+	KeepNewerThan time.Duration
+
+	// KeepDestinations keeps the releases distributed to one of these groups or stores
+	KeepDestinations []string
+}
+
+// Validate ensure the policy has at least one rule, to not delete all the releases by mistake
+func (p RetentionPolicy) Validate() error {
+	if p.KeepLast < 0 || p.KeepNewerThan < 0 {
+		return fmt.Errorf("Retention rules can not be negative")
+	}
+
+	if p.KeepLast == 0 && p.KeepNewerThan == 0 && len(p.KeepDestinations) == 0 {
+		return fmt.Errorf("The retention policy must define at least one rule")
+	}
+
+	return nil
+}
+
+// Apply splits the releases between the ones retained by the policy and the ones to prune. Both
+// are sorted from the most recent to the oldest release.
+func (p RetentionPolicy) Apply(releases []Release, now time.Time) (keep []Release, prune []Release) {
+	sorted := append([]Release{}, releases...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ID > sorted[j].ID
+	})
+
+	for i, r := range sorted {
+		if p.retains(i, r, now) {
+			keep = append(keep, r)
+		} else {
+			prune = append(prune, r)
+		}
+	}
+
+	return keep, prune
+}
+
+func (p RetentionPolicy) retains(index int, r Release, now time.Time) bool {
+	if index < p.KeepLast {
+		return true
+	}
+
+	if p.KeepNewerThan > 0 {
+		uploadedAt, err := time.Parse(time.RFC3339, r.UploadedAt)
+		// a release with an unknown upload date is never pruned on age
+		if err != nil || now.Sub(uploadedAt) < p.KeepNewerThan {
+			return true
+		}
+	}
+
+	for _, d := range r.Destinations {
+		for _, name := range p.KeepDestinations {
+			if d.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Prune deletes the provided releases
+func (s *ReleaseService) Prune(ctx context.Context, releases []Release) error {
+	sp, err := s.client.UI.Start("Deleting releases")
+	if err != nil {
+		return err
+	}
+
+	for i, r := range releases {
+		sp.UpdateText(fmt.Sprintf("Deleting release %d (%d/%d)", r.ID, i+1, len(releases)))
+		if err := s.Delete(ctx, r.ID); err != nil {
+			sp.Fail(fmt.Sprintf("Failed to delete release %d", r.ID))
+			return err
+		}
+	}
+
+	sp.Success(fmt.Sprintf("%d releases deleted", len(releases)))
+
+	return nil
+}
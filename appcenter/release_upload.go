@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-
-	"github.com/rs/zerolog/log"
 )
 
 type uploadReeleaseResponse struct {
@@ -15,7 +13,7 @@ type uploadReeleaseResponse struct {
 
 // ReleaseUpload will release the uploaded file to AppCenter
 func (s *UploadService) ReleaseUpload(ctx context.Context, uploadID string) error {
-	log.Info().
+	s.client.logger(ctx).Info().
 		Str("UploadID", uploadID).
 		Msg("Releasing upload")
 
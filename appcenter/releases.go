@@ -0,0 +1,187 @@
+package appcenter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ReleaseService definition
+type ReleaseService struct {
+	client *Client
+}
+
+// ReleaseDestination is a group, tester or store a release was distributed to
+type ReleaseDestination struct {
+	ID               string `json:"id,omitempty"`
+	Name             string `json:"name,omitempty"`
+	DestinationType  string `json:"destination_type,omitempty"`
+	PublishingStatus string `json:"publishing_status,omitempty"`
+}
+
+// Release is the basic release definition as returned by the releases listing
+type Release struct {
+	ID           int64                `json:"id"`
+	Version      string               `json:"version,omitempty"`
+	ShortVersion string               `json:"short_version,omitempty"`
+	Origin       string               `json:"origin,omitempty"`
+	UploadedAt   string               `json:"uploaded_at,omitempty"`
+	Enabled      bool                 `json:"enabled,omitempty"`
+	Destinations []ReleaseDestination `json:"destinations,omitempty"`
+}
+
+// ReleaseQuery describes how to resolve a release: by identifier, by build version and/or build
+// number, or as the latest release of the application
+type ReleaseQuery struct {
+	ID           int64
+	BuildVersion string
+	BuildNumber  string
+	Latest       bool
+}
+
+func (q ReleaseQuery) validate() error {
+	modes := 0
+	if q.ID > 0 {
+		modes++
+	}
+	if q.BuildVersion != "" || q.BuildNumber != "" {
+		modes++
+	}
+	if q.Latest {
+		modes++
+	}
+
+	if modes != 1 {
+		return fmt.Errorf("A release must be selected by either its ID, " +
+			"its build version/build number or as the latest one")
+	}
+
+	return nil
+}
+
+// matches returns true if the release has the build version and build number of the query. The
+// build version is the AppCenter "short version" and the build number the AppCenter "version"
+func (q ReleaseQuery) matches(r Release) bool {
+	if q.BuildVersion != "" && q.BuildVersion != r.ShortVersion {
+		return false
+	}
+
+	if q.BuildNumber != "" && q.BuildNumber != r.Version {
+		return false
+	}
+
+	return true
+}
+
+// List returns all the releases of the application
+func (s *ReleaseService) List(ctx context.Context) ([]Release, error) {
+	res := []Release{}
+	err := s.client.paginate(ctx, "releases", nil, func(item json.RawMessage) error {
+		var r Release
+		if err := json.Unmarshal(item, &r); err != nil {
+			return err
+		}
+
+		res = append(res, r)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Delete deletes the release with the provided identifier
+func (s *ReleaseService) Delete(ctx context.Context, id int64) error {
+	return s.client.NewAPIRequest(ctx, http.MethodDelete, fmt.Sprintf("releases/%v", id), nil, nil)
+}
+
+type releaseNotesBody struct {
+	ReleaseNotes string `json:"release_notes"`
+}
+
+// UpdateNotes replaces the release notes of the release with the provided identifier
+func (s *ReleaseService) UpdateNotes(ctx context.Context, id int64, notes string) error {
+	return s.client.NewAPIRequest(
+		ctx,
+		http.MethodPatch,
+		fmt.Sprintf("releases/%v", id),
+		releaseNotesBody{ReleaseNotes: notes},
+		nil,
+	)
+}
+
+// Get returns the details of the release with the provided identifier
+func (s *ReleaseService) Get(ctx context.Context, id int64) (*ReleaseDetails, error) {
+	return s.get(ctx, fmt.Sprintf("%v", id))
+}
+
+// Latest returns the details of the latest release of the application
+func (s *ReleaseService) Latest(ctx context.Context) (*ReleaseDetails, error) {
+	return s.get(ctx, "latest")
+}
+
+func (s *ReleaseService) get(ctx context.Context, id string) (*ReleaseDetails, error) {
+	var res ReleaseDetails
+	if err := s.client.NewAPIRequest(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("releases/%v", id),
+		nil,
+		&res,
+	); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Resolve returns the details of the release matching the provided query
+func (s *ReleaseService) Resolve(ctx context.Context, q ReleaseQuery) (*ReleaseDetails, error) {
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+
+	sp, err := s.client.UI.Start("Resolving release")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.resolve(ctx, q)
+	if err != nil {
+		sp.Fail()
+		return nil, err
+	}
+
+	sp.Success(fmt.Sprintf("Release resolved (ID: %d Version: %v (%v))", res.ID, res.ShortVersion, res.Version))
+
+	return res, nil
+}
+
+func (s *ReleaseService) resolve(ctx context.Context, q ReleaseQuery) (*ReleaseDetails, error) {
+	if q.ID > 0 {
+		return s.Get(ctx, q.ID)
+	}
+
+	if q.Latest {
+		return s.Latest(ctx)
+	}
+
+	releases, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the listing is sorted from the most recent to the oldest release
+	for _, r := range releases {
+		if q.matches(r) {
+			return s.Get(ctx, r.ID)
+		}
+	}
+
+	return nil, NewAppCenterError(ReleaseNotFoundError,
+		fmt.Errorf("no release with build version '%v' and build number '%v'", q.BuildVersion, q.BuildNumber))
+}
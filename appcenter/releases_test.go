@@ -0,0 +1,115 @@
+package appcenter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseQueryValidation(t *testing.T) {
+	testCases := []struct {
+		query ReleaseQuery
+		err   bool
+	}{
+		{ReleaseQuery{}, true},
+		{ReleaseQuery{ID: 12}, false},
+		{ReleaseQuery{BuildVersion: "1.2.3"}, false},
+		{ReleaseQuery{BuildNumber: "45"}, false},
+		{ReleaseQuery{BuildVersion: "1.2.3", BuildNumber: "45"}, false},
+		{ReleaseQuery{Latest: true}, false},
+		{ReleaseQuery{ID: 12, Latest: true}, true},
+		{ReleaseQuery{ID: 12, BuildVersion: "1.2.3"}, true},
+		{ReleaseQuery{BuildNumber: "45", Latest: true}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("For query %+v", tc.query), func(t *testing.T) {
+			err := tc.query.validate()
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReleaseQueryMatching(t *testing.T) {
+	r := Release{ID: 1, ShortVersion: "1.2.3", Version: "45"}
+
+	assert.True(t, ReleaseQuery{BuildVersion: "1.2.3"}.matches(r))
+	assert.True(t, ReleaseQuery{BuildNumber: "45"}.matches(r))
+	assert.True(t, ReleaseQuery{BuildVersion: "1.2.3", BuildNumber: "45"}.matches(r))
+	assert.False(t, ReleaseQuery{BuildVersion: "1.2.4"}.matches(r))
+	assert.False(t, ReleaseQuery{BuildVersion: "1.2.3", BuildNumber: "46"}.matches(r))
+}
+
+func TestRetentionPolicy(t *testing.T) {
+	now := time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC)
+
+	releases := []Release{
+		{ID: 1, UploadedAt: "2020-11-01T00:00:00Z",
+			Destinations: []ReleaseDestination{{Name: "Production"}}},
+		{ID: 2, UploadedAt: "2020-11-02T00:00:00Z"},
+		{ID: 5, UploadedAt: "2020-12-14T00:00:00Z"},
+		{ID: 3, UploadedAt: "2020-12-01T00:00:00Z"},
+		{ID: 4, UploadedAt: "2020-12-10T00:00:00Z"},
+	}
+
+	ids := func(releases []Release) []int64 {
+		res := []int64{}
+		for _, r := range releases {
+			res = append(res, r.ID)
+		}
+		return res
+	}
+
+	testCases := []struct {
+		name   string
+		policy RetentionPolicy
+		keep   []int64
+		prune  []int64
+	}{
+		{"Keep last", RetentionPolicy{KeepLast: 2}, []int64{5, 4}, []int64{3, 2, 1}},
+		{"Keep newer than", RetentionPolicy{KeepNewerThan: 10 * 24 * time.Hour}, []int64{5, 4}, []int64{3, 2, 1}},
+		{"Keep destinations", RetentionPolicy{KeepDestinations: []string{"Production"}}, []int64{1}, []int64{5, 4, 3, 2}},
+		{"Combined rules", RetentionPolicy{
+			KeepLast:         1,
+			KeepNewerThan:    20 * 24 * time.Hour,
+			KeepDestinations: []string{"Production"},
+		}, []int64{5, 4, 3, 1}, []int64{2}},
+		{"Keep more than available", RetentionPolicy{KeepLast: 10}, []int64{5, 4, 3, 2, 1}, []int64{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NoError(t, tc.policy.Validate())
+
+			keep, prune := tc.policy.Apply(releases, now)
+			assert.Equal(t, tc.keep, ids(keep))
+			assert.Equal(t, tc.prune, ids(prune))
+		})
+	}
+
+	t.Run("An empty policy is invalid", func(t *testing.T) {
+		assert.Error(t, RetentionPolicy{}.Validate())
+	})
+}
+
+func TestUpdateReleaseNotes(t *testing.T) {
+	var body releaseNotesBody
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/apps/owner/app/releases/12", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	})
+	defer done()
+
+	assert.NoError(t, c.Releases.UpdateNotes(context.Background(), 12, "Bug fixes"))
+	assert.Equal(t, "Bug fixes", body.ReleaseNotes)
+}
@@ -0,0 +1,57 @@
+package appcenter
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// retryAttempts is the number of attempts of the retried requests
+const retryAttempts = 3
+
+// retryDelay is the delay before the first retry, doubled after each attempt
+var retryDelay = time.Second
+
+// permanentError is an error which is not worth retrying, as a rejected request
+type permanentError struct {
+	error
+}
+
+// permanent marks the error as not worth retrying
+func permanent(err error) error {
+	return permanentError{err}
+}
+
+// retryableStatus reports whether a request failing with the HTTP status code is worth retrying:
+// timeouts, throttling and server errors. The other client errors fail the same way again.
+func retryableStatus(code int) bool {
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500
+}
+
+// retry calls fn until it succeeds, at most attempts times, waiting between the attempts. The
+// permanent errors are returned without retrying.
+func retry(ctx context.Context, attempts int, delay time.Duration, fn func() error) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		if p, ok := err.(permanentError); ok {
+			return p.error
+		}
+
+		if i == attempts-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+
+	return err
+}
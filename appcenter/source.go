@@ -0,0 +1,144 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	urlpath "path"
+	"path/filepath"
+	"strconv"
+)
+
+// Source is a binary to upload, read at random offsets
+type Source interface {
+	io.ReaderAt
+	io.Closer
+
+	// Name is the file name of the binary
+	Name() string
+
+	// Size is the size of the binary in bytes
+	Size() int64
+}
+
+// stdin is the input read by the stdin:// sources
+var stdin io.Reader = os.Stdin
+
+// OpenSource opens the binary at the provided location:
+//
+//	a local path
+//	stdin://<name>?size=<bytes> for the standard input
+//	http://... or https://... for a web server supporting range requests
+//	s3://<bucket>/<key> for an S3 compatible storage (see newS3Config)
+func OpenSource(ctx context.Context, location string) (Source, error) {
+	u, err := url.Parse(location)
+	if err != nil || !isRemote(location) {
+		return openFileSource(location)
+	}
+
+	switch u.Scheme {
+	case "stdin":
+		return openStdinSource(u)
+	case "http", "https":
+		return openHTTPSource(ctx, httpClient, u.String(), urlpath.Base(u.Path), nil)
+	case "s3":
+		return openS3Source(ctx, newS3Config(), u)
+	}
+
+	return nil, fmt.Errorf("Unsupported source scheme '%v'", u.Scheme)
+}
+
+// isRemote returns true if the location is not a local path
+func isRemote(location string) bool {
+	u, err := url.Parse(location)
+	// single letter schemes are Windows drive letters
+	return err == nil && len(u.Scheme) > 1
+}
+
+// sourceName returns the file name of the provided location
+func sourceName(location string) string {
+	if u, err := url.Parse(location); err == nil && isRemote(location) {
+		if u.Scheme == "stdin" {
+			return u.Host
+		}
+		return urlpath.Base(u.Path)
+	}
+
+	return filepath.Base(location)
+}
+
+// fileSource is a local binary
+type fileSource struct {
+	*os.File
+	name string
+	size int64
+
+	// remove the file once closed
+	temporary bool
+}
+
+func openFileSource(p string) (*fileSource, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &fileSource{File: f, name: fi.Name(), size: fi.Size()}, nil
+}
+
+func (s *fileSource) Name() string {
+	return s.name
+}
+
+func (s *fileSource) Size() int64 {
+	return s.size
+}
+
+func (s *fileSource) Close() error {
+	err := s.File.Close()
+	if s.temporary {
+		os.Remove(s.File.Name())
+	}
+	return err
+}
+
+// openStdinSource reads the standard input into a temporary file, as the uploads require random
+// access to the binary. The size of the binary must be provided to detect truncated inputs.
+func openStdinSource(u *url.URL) (*fileSource, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("The name of the binary must be provided: stdin://<name>?size=<bytes>")
+	}
+
+	size, err := strconv.ParseInt(u.Query().Get("size"), 10, 64)
+	if err != nil || size <= 0 {
+		return nil, fmt.Errorf("The size of the binary must be provided: stdin://<name>?size=<bytes>")
+	}
+
+	f, err := ioutil.TempFile("", "appcenter-*-"+u.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &fileSource{File: f, name: u.Host, size: size, temporary: true}
+
+	n, err := io.Copy(f, io.LimitReader(stdin, size))
+	if err == nil && n != size {
+		err = fmt.Errorf("Truncated standard input: %d bytes read out of %d", n, size)
+	}
+
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
@@ -0,0 +1,184 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+var (
+	// httpBlockSize size of the blocks read ahead by the smaller reads of the HTTP sources, the
+	// inspection of an archive making many reads of a few bytes
+	httpBlockSize int64 = 1 << 20
+
+	// httpCachedBlocks number of blocks kept by an HTTP source
+	httpCachedBlocks = 16
+)
+
+// httpSource is a binary served by a web server supporting range requests
+type httpSource struct {
+	ctx    context.Context
+	client *http.Client
+	url    string
+	name   string
+	size   int64
+
+	// sign is applied to the requests before they are sent
+	sign func(*http.Request) error
+
+	// blocks read ahead, by index, evicted in the order they were read
+	mu     sync.Mutex
+	blocks map[int64][]byte
+	order  []int64
+}
+
+func openHTTPSource(
+	ctx context.Context,
+	client *http.Client,
+	url string,
+	name string,
+	sign func(*http.Request) error,
+) (*httpSource, error) {
+	s := &httpSource{ctx: ctx, client: client, url: url, name: name, sign: sign, blocks: map[int64][]byte{}}
+
+	resp, err := s.request(http.MethodHead, "")
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to open '%v': %v", name, resp.Status)
+	}
+
+	if resp.ContentLength < 0 {
+		return nil, fmt.Errorf("Failed to open '%v': unknown size", name)
+	}
+
+	s.size = resp.ContentLength
+	return s, nil
+}
+
+func (s *httpSource) request(method string, rng string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(s.ctx, method, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if rng != "" {
+		req.Header.Set("Range", rng)
+	}
+
+	if s.sign != nil {
+		if err := s.sign(req); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.client.Do(req)
+}
+
+func (s *httpSource) Name() string {
+	return s.name
+}
+
+func (s *httpSource) Size() int64 {
+	return s.size
+}
+
+func (s *httpSource) Close() error {
+	return nil
+}
+
+// ReadAt reads the requested range of the binary. The reads smaller than a block are served from
+// the blocks read ahead, the other ones with a range request of their own.
+func (s *httpSource) ReadAt(p []byte, off int64) (int, error) {
+	if off >= s.size {
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) >= httpBlockSize {
+		return s.readRange(p, off)
+	}
+
+	n := 0
+	for n < len(p) && off+int64(n) < s.size {
+		pos := off + int64(n)
+		b, err := s.block(pos / httpBlockSize)
+		if err != nil {
+			return n, err
+		}
+		n += copy(p[n:], b[pos%httpBlockSize:])
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// block returns the block of the binary at the index, reading it when it is not cached
+func (s *httpSource) block(i int64) ([]byte, error) {
+	s.mu.Lock()
+	b, ok := s.blocks[i]
+	s.mu.Unlock()
+	if ok {
+		return b, nil
+	}
+
+	size := httpBlockSize
+	if rest := s.size - i*httpBlockSize; rest < size {
+		size = rest
+	}
+
+	b = make([]byte, size)
+	if _, err := s.readRange(b, i*httpBlockSize); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.blocks[i]; !ok {
+		s.blocks[i] = b
+		s.order = append(s.order, i)
+		if len(s.order) > httpCachedBlocks {
+			delete(s.blocks, s.order[0])
+			s.order = s.order[1:]
+		}
+	}
+
+	return b, nil
+}
+
+// readRange reads the range of the binary with a range request
+func (s *httpSource) readRange(p []byte, off int64) (int, error) {
+	end := off + int64(len(p))
+	if end > s.size {
+		end = s.size
+	}
+
+	if end == off {
+		return 0, nil
+	}
+
+	resp, err := s.request(http.MethodGet, fmt.Sprintf("bytes=%d-%d", off, end-1))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		return 0, fmt.Errorf("Range request on '%v' failed: %v", s.name, resp.Status)
+	}
+
+	n, err := io.ReadFull(resp.Body, p[:end-off])
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+
+	return n, err
+}
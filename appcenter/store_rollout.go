@@ -0,0 +1,184 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// googlePlayTracks are the Google Play tracks in promotion order
+var googlePlayTracks = []string{"internal", "alpha", "beta", "production"}
+
+func trackIndex(track string) int {
+	for i, t := range googlePlayTracks {
+		if t == track {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// NextTrack returns the Google Play track following the provided one in the promotion order
+func NextTrack(track string) (string, error) {
+	i := trackIndex(track)
+	if i < 0 {
+		return "", fmt.Errorf("Unknown Google Play track '%v'", track)
+	}
+
+	if i == len(googlePlayTracks)-1 {
+		return "", fmt.Errorf("The '%v' track can not be promoted", track)
+	}
+
+	return googlePlayTracks[i+1], nil
+}
+
+// storeForTrack returns the Google Play store connected for the provided track
+func storeForTrack(stores []Store, track string) (*Store, error) {
+	for _, store := range stores {
+		if store.Type == StoreTypeGooglePlay && store.Track == track {
+			return &store, nil
+		}
+	}
+
+	return nil, fmt.Errorf("No Google Play store connected for the '%v' track", track)
+}
+
+// Promote publishes the release, already published to the Google Play store of fromTrack, to the
+// Google Play store of toTrack. When toTrack is empty, the release is promoted to the next track
+func (s *StoreService) Promote(
+	ctx context.Context,
+	releaseID int64,
+	fromTrack string,
+	toTrack string,
+	opts PublishOptions,
+) error {
+	if toTrack == "" {
+		next, err := NextTrack(fromTrack)
+		if err != nil {
+			return err
+		}
+		toTrack = next
+	}
+
+	if trackIndex(fromTrack) < 0 || trackIndex(toTrack) < 0 {
+		return fmt.Errorf("Unknown Google Play track '%v' or '%v'", fromTrack, toTrack)
+	}
+
+	if trackIndex(toTrack) <= trackIndex(fromTrack) {
+		return fmt.Errorf("A release can not be promoted from '%v' to '%v'", fromTrack, toTrack)
+	}
+
+	sp, err := s.client.UI.Start(fmt.Sprintf("Resolving Google Play stores of tracks '%v' and '%v'", fromTrack, toTrack))
+	if err != nil {
+		return err
+	}
+
+	from, to, err := s.promotionStores(ctx, releaseID, fromTrack, toTrack)
+	if err != nil {
+		sp.Fail()
+		return err
+	}
+
+	sp.Success(fmt.Sprintf("Promoting release %d from '%v' to '%v'", releaseID, from.Name, to.Name))
+
+	return s.PublishToStore(ctx, releaseID, to, opts)
+}
+
+func (s *StoreService) promotionStores(
+	ctx context.Context,
+	releaseID int64,
+	fromTrack string,
+	toTrack string,
+) (*Store, *Store, error) {
+	stores, err := s.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from, err := storeForTrack(stores, fromTrack)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to, err := storeForTrack(stores, toTrack)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status, _, err := s.publishingStatus(ctx, releaseID, from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if status != PublishingStatusPublished {
+		return nil, nil, fmt.Errorf("Release %d is not published to '%v'", releaseID, from.Name)
+	}
+
+	return from, to, nil
+}
+
+// Rollback publishes again to the store the release published before the current one of the
+// store, and returns it
+func (s *StoreService) Rollback(ctx context.Context, storeName string, opts PublishOptions) (*ReleaseDetails, error) {
+	sp, err := s.client.UI.Start(fmt.Sprintf("Resolving the previous release of '%v'", storeName))
+	if err != nil {
+		return nil, err
+	}
+
+	store, previous, err := s.previousRelease(ctx, storeName)
+	if err != nil {
+		sp.Fail()
+		return nil, err
+	}
+
+	sp.Success(fmt.Sprintf("Rolling back '%v' to release %d (Version: %v (%v))",
+		store.Name, previous.ID, previous.ShortVersion, previous.Version))
+
+	return previous, s.PublishToStore(ctx, previous.ID, store, opts)
+}
+
+func (s *StoreService) previousRelease(ctx context.Context, storeName string) (*Store, *ReleaseDetails, error) {
+	store, err := s.Get(ctx, storeName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var current ReleaseDetails
+	if err := s.client.NewAPIRequest(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("distribution_stores/%s/releases/latest", url.PathEscape(store.Name)),
+		nil,
+		&current,
+	); err != nil {
+		return nil, nil, err
+	}
+
+	releases, err := s.client.Releases.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releases[i].ID > releases[j].ID
+	})
+
+	for _, r := range releases {
+		if r.ID >= current.ID {
+			continue
+		}
+
+		for _, d := range r.Destinations {
+			if d.ID == store.ID {
+				previous, err := s.client.Releases.Get(ctx, r.ID)
+				return store, previous, err
+			}
+		}
+	}
+
+	return nil, nil, NewAppCenterError(ReleaseNotFoundError,
+		fmt.Errorf("no release was published to '%v' before release %d", store.Name, current.ID))
+}
@@ -0,0 +1,266 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// StoreTypeGooglePlay Google Play store
+	StoreTypeGooglePlay = "googleplay"
+
+	// StoreTypeIntune Microsoft Intune company portal
+	StoreTypeIntune = "intune"
+
+	// StoreTypeAppleStore App Store Connect
+	StoreTypeAppleStore = "apple"
+
+	// PublishingStatusPublished the release was published to the store
+	PublishingStatusPublished = "published"
+
+	// PublishingStatusFailed the release publishing to the store failed
+	PublishingStatusFailed = "failed"
+)
+
+var (
+	// storePollInterval interval between two requests of the store publishing status
+	storePollInterval = 10 * time.Second
+
+	// storePollTimeout maximum duration to wait for the store publishing to complete
+	storePollTimeout = 30 * time.Minute
+
+	// storeMissingPolls number of polls after which a store missing from the release destinations fails
+	storeMissingPolls = 3
+)
+
+// StoreService definition
+type StoreService struct {
+	client *Client
+}
+
+// Store is a store connected to the application
+type Store struct {
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	Type                string `json:"type"`
+	Track               string `json:"track,omitempty"`
+	ServiceConnectionID string `json:"service_connection_id,omitempty"`
+}
+
+// PublishOptions are the options of the release publishing to a store
+type PublishOptions struct {
+	// Wait for AppCenter to report the publishing as succeeded or failed
+	Wait bool
+
+	// RolloutFraction is the fraction of the users receiving the release, for staged rollouts on
+	// the Google Play production track. Zero publishes the release to all the users
+	RolloutFraction float64
+}
+
+func (o PublishOptions) validate(store *Store) error {
+	if o.RolloutFraction == 0 {
+		return nil
+	}
+
+	if store.Type != StoreTypeGooglePlay {
+		return fmt.Errorf("Staged rollouts are only supported by Google Play stores ('%v' is of type '%v')",
+			store.Name, store.Type)
+	}
+
+	if o.RolloutFraction < 0 || o.RolloutFraction > 1 {
+		return fmt.Errorf("The rollout fraction must be between 0 and 1 (got %v)", o.RolloutFraction)
+	}
+
+	return nil
+}
+
+type distributionStoreBody struct {
+	ID           string  `json:"id"`
+	UserFraction float64 `json:"user_fraction,omitempty"`
+}
+
+type publishErrorDetailsResponse struct {
+	Message string `json:"message"`
+}
+
+// List returns the stores connected to the application
+func (s *StoreService) List(ctx context.Context) ([]Store, error) {
+	var res []Store
+	if err := s.client.NewAPIRequest(ctx, http.MethodGet, "distribution_stores", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Get returns the connected store with the provided name
+func (s *StoreService) Get(ctx context.Context, storeName string) (*Store, error) {
+	var res Store
+	if err := s.client.NewAPIRequest(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("distribution_stores/%s", url.PathEscape(storeName)),
+		nil,
+		&res,
+	); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Publish publishes the release to the store with the provided name
+func (s *StoreService) Publish(ctx context.Context, releaseID int64, storeName string, opts PublishOptions) error {
+	sp, err := s.client.UI.Start(fmt.Sprintf("Requesting distribution store ID from name '%v'", storeName))
+	if err != nil {
+		return err
+	}
+
+	store, err := s.Get(ctx, storeName)
+	if err != nil {
+		sp.Fail()
+		return err
+	}
+
+	sp.UpdateText(fmt.Sprintf("Distribution store ID resolved: %v", store.ID))
+	sp.Success()
+
+	return s.PublishToStore(ctx, releaseID, store, opts)
+}
+
+// PublishToStore publishes the release to the provided store
+func (s *StoreService) PublishToStore(ctx context.Context, releaseID int64, store *Store, opts PublishOptions) error {
+	if err := opts.validate(store); err != nil {
+		return err
+	}
+
+	if err := s.releaseToStore(ctx, releaseID, store, opts); err != nil {
+		return err
+	}
+
+	if opts.Wait {
+		return s.PollForPublishing(ctx, releaseID, store)
+	}
+
+	return nil
+}
+
+func (s *StoreService) releaseToStore(ctx context.Context, releaseID int64, store *Store, opts PublishOptions) error {
+	sp, err := s.client.UI.Start(fmt.Sprintf("Releasing to store '%v'", store.Name))
+	if err != nil {
+		return err
+	}
+
+	body := distributionStoreBody{ID: store.ID, UserFraction: opts.RolloutFraction}
+
+	r := distributionResponse{}
+
+	path := fmt.Sprintf("releases/%v/stores", releaseID)
+
+	err = s.client.NewAPIRequest(ctx, http.MethodPost, path, &body, &r)
+	if err != nil {
+		sp.Fail()
+		return NewAppCenterError(StorePublishingError, err)
+	}
+
+	sp.Success()
+
+	return nil
+}
+
+// PollForPublishing will poll AppCenter till the release publishing to the store succeeded or failed
+func (s *StoreService) PollForPublishing(ctx context.Context, releaseID int64, store *Store) error {
+	sp, err := s.client.UI.Start(fmt.Sprintf("Waiting for the release to be published to '%v'", store.Name))
+	if err != nil {
+		return err
+	}
+
+	t := time.NewTicker(storePollInterval)
+	defer t.Stop()
+
+	timeout := time.After(storePollTimeout)
+
+	missing := 0
+	for {
+		status, found, err := s.publishingStatus(ctx, releaseID, store)
+		if err != nil {
+			sp.Fail()
+			return err
+		}
+
+		// the destinations may not list the store yet right after the release
+		if !found {
+			missing++
+			if missing >= storeMissingPolls {
+				sp.Fail(fmt.Sprintf("The release is not distributed to '%v'", store.Name))
+				return NewAppCenterError(StorePublishingError,
+					fmt.Errorf("store '%v' missing from the destinations of release %v", store.Name, releaseID))
+			}
+		} else {
+			missing = 0
+		}
+
+		switch status {
+		case PublishingStatusPublished:
+			sp.Success(fmt.Sprintf("Release published to '%v'", store.Name))
+			return nil
+
+		case PublishingStatusFailed:
+			sp.Fail(fmt.Sprintf("Release publishing to '%v' failed", store.Name))
+			return NewAppCenterError(StorePublishingError, s.publishError(ctx, releaseID, store))
+		}
+
+		sp.UpdateText(fmt.Sprintf("Waiting for the release to be published to '%v' (Status: %v)", store.Name, status))
+
+		select {
+		case <-ctx.Done():
+			sp.Fail()
+			return NewAppCenterError(StorePollingError, ctx.Err())
+		case <-timeout:
+			sp.Fail()
+			return NewAppCenterError(StorePollingError, nil)
+		case <-t.C:
+		}
+	}
+}
+
+// publishingStatus returns the publishing status of the release for the store, as reported in the
+// destinations of the release, and whether the store is one of the destinations. The status is
+// empty until the store starts publishing the release.
+func (s *StoreService) publishingStatus(ctx context.Context, releaseID int64, store *Store) (string, bool, error) {
+	release, err := s.client.Releases.Get(ctx, releaseID)
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, d := range release.Destinations {
+		if d.ID == store.ID {
+			return d.PublishingStatus, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// publishError returns the reason of the publishing failure as reported by the store
+func (s *StoreService) publishError(ctx context.Context, releaseID int64, store *Store) error {
+	var res publishErrorDetailsResponse
+	if err := s.client.NewAPIRequest(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("distribution_stores/%s/releases/%v/publish_error_details", url.PathEscape(store.Name), releaseID),
+		nil,
+		&res,
+	); err != nil {
+		return err
+	}
+
+	if res.Message == "" {
+		return nil
+	}
+
+	return fmt.Errorf("%v", res.Message)
+}
@@ -0,0 +1,116 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorePublishing(t *testing.T) {
+	defer func(interval time.Duration) { storePollInterval = interval }(storePollInterval)
+	storePollInterval = time.Millisecond
+
+	// notDestination marks a release not distributed to the store yet
+	const notDestination = "-"
+
+	publish := func(statuses ...string) error {
+		count := 0
+		c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/apps/owner/app/distribution_stores/Production":
+				fmt.Fprint(w, `{"id": "store-id", "name": "Production", "type": "googleplay", "track": "production"}`)
+			case "/apps/owner/app/releases/12/stores":
+				assert.Equal(t, http.MethodPost, r.Method)
+				fmt.Fprint(w, `{"id": "store-id"}`)
+			case "/apps/owner/app/releases/12":
+				status := statuses[count]
+				if count < len(statuses)-1 {
+					count++
+				}
+				switch status {
+				case notDestination:
+					fmt.Fprint(w, `{"id": 12, "destinations": [{"id": "group-id"}]}`)
+					return
+				case "":
+					fmt.Fprint(w, `{"id": 12, "destinations": [{"id": "store-id"}]}`)
+					return
+				}
+				fmt.Fprintf(w, `{"id": 12, "destinations": [{"id": "store-id", "publishing_status": "%v"}]}`, status)
+			case "/apps/owner/app/distribution_stores/Production/releases/12/publish_error_details":
+				fmt.Fprint(w, `{"message": "Version code 45 has already been used"}`)
+			default:
+				t.Errorf("Unexpected request to %v", r.URL.Path)
+			}
+		})
+		defer done()
+
+		return c.Stores.Publish(context.Background(), 12, "Production", PublishOptions{Wait: true})
+	}
+
+	t.Run("Should wait for the release to be published", func(t *testing.T) {
+		assert.NoError(t, publish("submitted", "submitted", PublishingStatusPublished))
+	})
+
+	t.Run("Should wait for the publishing status to be set", func(t *testing.T) {
+		assert.NoError(t, publish(notDestination, "", "", "submitted", PublishingStatusPublished))
+	})
+
+	t.Run("Should report the store publishing failure", func(t *testing.T) {
+		err := publish("submitted", PublishingStatusFailed)
+		assert.EqualError(t, err, "AppCenter error: Store publishing failed (Version code 45 has already been used)")
+	})
+
+	t.Run("Should fail when the store is not a destination of the release", func(t *testing.T) {
+		err := publish(notDestination, notDestination, "", notDestination, notDestination, notDestination, "submitted")
+		assert.EqualError(t, err, "AppCenter error: Store publishing failed (store 'Production' missing from the destinations of release 12)")
+	})
+}
+
+func TestGooglePlayTracks(t *testing.T) {
+	next, err := NextTrack("alpha")
+	assert.NoError(t, err)
+	assert.Equal(t, "beta", next)
+
+	_, err = NextTrack("production")
+	assert.Error(t, err)
+
+	_, err = NextTrack("unknown")
+	assert.Error(t, err)
+
+	googlePlay := &Store{Name: "Production", Type: StoreTypeGooglePlay}
+	assert.NoError(t, PublishOptions{RolloutFraction: 0.1}.validate(googlePlay))
+	assert.Error(t, PublishOptions{RolloutFraction: 1.5}.validate(googlePlay))
+	assert.Error(t, PublishOptions{RolloutFraction: 0.1}.validate(&Store{Name: "Intune", Type: StoreTypeIntune}))
+}
+
+func TestStoreRollback(t *testing.T) {
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apps/owner/app/distribution_stores/Production":
+			fmt.Fprint(w, `{"id": "store-id", "name": "Production", "type": "googleplay", "track": "production"}`)
+		case "/apps/owner/app/distribution_stores/Production/releases/latest":
+			fmt.Fprint(w, `{"id": 12}`)
+		case "/apps/owner/app/releases":
+			fmt.Fprint(w, `[{"id": 13, "destinations": [{"id": "store-id"}]},
+				{"id": 12, "destinations": [{"id": "store-id"}]},
+				{"id": 11, "destinations": [{"id": "group-id"}]},
+				{"id": 10, "destinations": [{"id": "store-id"}]}]`)
+		case "/apps/owner/app/releases/10":
+			fmt.Fprint(w, `{"id": 10, "short_version": "1.0.0", "version": "10"}`)
+		case "/apps/owner/app/releases/10/stores":
+			assert.Equal(t, http.MethodPost, r.Method)
+			fmt.Fprint(w, `{"id": "store-id"}`)
+		default:
+			t.Errorf("Unexpected request to %v", r.URL.Path)
+		}
+	})
+	defer done()
+
+	release, err := c.Stores.Rollback(context.Background(), "Production", PublishOptions{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, release.ID)
+}
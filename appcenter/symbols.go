@@ -0,0 +1,256 @@
+package appcenter
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// SymbolTypeApple dSYM bundles, zipped
+	SymbolTypeApple = "Apple"
+
+	// SymbolTypeAndroidProguard ProGuard or R8 mapping.txt files
+	SymbolTypeAndroidProguard = "AndroidProguard"
+
+	// SymbolTypeBreakpad Breakpad symbols, zipped
+	SymbolTypeBreakpad = "Breakpad"
+
+	// SymbolTypeUWP UWP symbols (.appxsym)
+	SymbolTypeUWP = "UWP"
+)
+
+// symbolTypes maps the short names of the symbol types to their AppCenter names
+var symbolTypes = map[string]string{
+	"dsym":     SymbolTypeApple,
+	"mapping":  SymbolTypeAndroidProguard,
+	"breakpad": SymbolTypeBreakpad,
+	"uwp":      SymbolTypeUWP,
+}
+
+// SymbolService definition
+type SymbolService struct {
+	client *Client
+}
+
+// SymbolUploadTask wraps the arguments of a symbol upload
+type SymbolUploadTask struct {
+	// Type is the AppCenter symbol type (SymbolTypeApple, SymbolTypeAndroidProguard...)
+	Type string
+
+	// FilePath is the symbols file, or a directory (such as a .dSYM bundle) zipped before the
+	// upload. Remote locations are supported (see OpenSource).
+	FilePath string
+
+	// Source is the symbols file to upload, opened from FilePath when nil
+	Source Source
+
+	// Version is the version name of the release, required for Android mappings
+	Version string
+
+	// Build is the version code of the release, required for Android mappings
+	Build string
+}
+
+// SymbolUpload is a symbol upload session
+type SymbolUpload struct {
+	ID             string `json:"symbol_upload_id"`
+	UploadURL      string `json:"upload_url"`
+	ExpirationDate string `json:"expiration_date"`
+}
+
+type symbolUploadBody struct {
+	SymbolType string `json:"symbol_type"`
+	FileName   string `json:"file_name,omitempty"`
+	Build      string `json:"build,omitempty"`
+	Version    string `json:"version,omitempty"`
+}
+
+type symbolUploadStatusBody struct {
+	Status string `json:"status"`
+}
+
+// ParseSymbolType returns the AppCenter symbol type of the short name: dsym, mapping, breakpad
+// or uwp
+func ParseSymbolType(name string) (string, error) {
+	if t, ok := symbolTypes[strings.ToLower(name)]; ok {
+		return t, nil
+	}
+
+	return "", NewAppCenterError(SymbolUploadError,
+		fmt.Errorf("Unsupported symbol type '%v', expecting dsym, mapping, breakpad or uwp", name))
+}
+
+func (t SymbolUploadTask) validate() error {
+	switch t.Type {
+	case SymbolTypeApple, SymbolTypeBreakpad, SymbolTypeUWP:
+	case SymbolTypeAndroidProguard:
+		if t.Version == "" || t.Build == "" {
+			return NewAppCenterError(SymbolUploadError,
+				fmt.Errorf("The version name and version code are required to upload Android mappings"))
+		}
+	default:
+		return NewAppCenterError(SymbolUploadError, fmt.Errorf("Unsupported symbol type '%v'", t.Type))
+	}
+
+	if t.Source == nil && t.FilePath == "" {
+		return NewAppCenterError(InputFileError, fmt.Errorf("No symbols file provided"))
+	}
+
+	return nil
+}
+
+// open returns the symbols to upload, zipping them first when they are a directory
+func (t SymbolUploadTask) open(ctx context.Context) (Source, error) {
+	if t.Source != nil {
+		return t.Source, nil
+	}
+
+	if fi, err := os.Stat(t.FilePath); err == nil && fi.IsDir() {
+		return zipDirectories(filepath.Base(filepath.Clean(t.FilePath))+".zip", t.FilePath)
+	}
+
+	return OpenSource(ctx, t.FilePath)
+}
+
+// Upload uploads the symbols: an upload session is started, the symbols are uploaded to the
+// blob storage, then the session is committed (or aborted in case of error)
+func (s *SymbolService) Upload(ctx context.Context, t SymbolUploadTask) (*SymbolUpload, error) {
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+
+	src, err := t.open(ctx)
+	if err != nil {
+		return nil, NewAppCenterError(InputFileError, err)
+	}
+	if t.Source == nil {
+		defer src.Close()
+	}
+
+	sp, err := s.client.UI.Start(fmt.Sprintf("Uploading %v symbols '%v'", t.Type, src.Name()))
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := s.begin(ctx, t, src.Name())
+	if err != nil {
+		sp.Fail()
+		return nil, NewAppCenterError(SymbolUploadError, err)
+	}
+
+	if err := s.client.uploadBlob(ctx, upload.UploadURL, src); err != nil {
+		sp.Fail()
+		//nolint:errcheck
+		s.setStatus(ctx, upload.ID, SymbolUploadAborted)
+		return nil, NewAppCenterError(SymbolUploadError, err)
+	}
+
+	if err := s.setStatus(ctx, upload.ID, SymbolUploadCommitted); err != nil {
+		sp.Fail()
+		return nil, NewAppCenterError(SymbolUploadError, err)
+	}
+
+	sp.Success(fmt.Sprintf("Symbols uploaded (ID: %v)", upload.ID))
+
+	return upload, nil
+}
+
+func (s *SymbolService) begin(ctx context.Context, t SymbolUploadTask, name string) (*SymbolUpload, error) {
+	body := symbolUploadBody{
+		SymbolType: t.Type,
+		FileName:   name,
+		Build:      t.Build,
+		Version:    t.Version,
+	}
+
+	var res SymbolUpload
+	if err := s.client.NewAPIRequest(ctx, http.MethodPost, "symbol_uploads", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *SymbolService) setStatus(ctx context.Context, id string, status string) error {
+	return s.client.NewAPIRequest(
+		ctx,
+		http.MethodPatch,
+		fmt.Sprintf("symbol_uploads/%v", id),
+		symbolUploadStatusBody{Status: status},
+		nil,
+	)
+}
+
+// zipDirectories zips the directories into a temporary file removed once the source is closed.
+// The directories themselves are the roots of the archive, as expected for .dSYM bundles.
+func zipDirectories(name string, dirs ...string) (Source, error) {
+	f, err := ioutil.TempFile("", "appcenter-*.zip")
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeZipDirectories(f, dirs)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	src, err := openFileSource(f.Name())
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	src.name = name
+	src.temporary = true
+	return src, nil
+}
+
+func writeZipDirectories(w io.Writer, dirs []string) error {
+	z := zip.NewWriter(w)
+
+	for _, dir := range dirs {
+		root := filepath.Dir(filepath.Clean(dir))
+
+		err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+			if err != nil || fi.IsDir() {
+				return err
+			}
+
+			name, err := filepath.Rel(root, p)
+			if err != nil {
+				return err
+			}
+
+			entry, err := z.Create(filepath.ToSlash(name))
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			_, err = io.Copy(entry, f)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return z.Close()
+}
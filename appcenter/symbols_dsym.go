@@ -0,0 +1,133 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"goappcenter/inspect"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// DSYMReport is the result of the pairing of the dSYMs with the executables of an IPA
+type DSYMReport struct {
+	// Covered maps the UUIDs of the executables to the dSYM providing their symbols
+	Covered map[string]string `json:"covered"`
+
+	// Missing are the UUIDs of the executables without dSYM
+	Missing []string `json:"missing,omitempty"`
+
+	// Upload is the symbol upload of the matching dSYMs, nil if none was found. Its upload URL
+	// holds a SAS token, only the ID of the upload is part of the JSON output.
+	Upload *SymbolUpload `json:"-"`
+
+	// UploadID is the ID of the symbol upload, empty if none was found
+	UploadID string `json:"symbol_upload_id,omitempty"`
+}
+
+// FindDSYMs looks up the directory recursively for the .dSYM bundles providing the symbols of
+// the UUIDs. The unreadable dSYMs are skipped, with a warning logged to l.
+func FindDSYMs(root string, uuids []string, l *zerolog.Logger) (*DSYMReport, []string, error) {
+	report := &DSYMReport{Covered: map[string]string{}}
+	wanted := map[string]bool{}
+	for _, u := range uuids {
+		wanted[u] = true
+	}
+
+	dsyms := []string{}
+	err := filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || !fi.IsDir() || !strings.HasSuffix(fi.Name(), ".dSYM") {
+			return err
+		}
+
+		found, err := inspect.DSYMUUIDs(p)
+		if err != nil {
+			l.Warn().Err(err).Str("dSYM", p).Msg("Failed to read the dSYM UUIDs")
+			return filepath.SkipDir
+		}
+
+		matched := false
+		for _, u := range found {
+			if wanted[u] {
+				report.Covered[u] = p
+				matched = true
+			}
+		}
+
+		if matched {
+			dsyms = append(dsyms, p)
+		}
+
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, nil, NewAppCenterError(InputFileError, err)
+	}
+
+	for _, u := range uuids {
+		if _, ok := report.Covered[u]; !ok {
+			report.Missing = append(report.Missing, u)
+		}
+	}
+
+	return report, dsyms, nil
+}
+
+// UploadDSYMs uploads the dSYMs found in the directory matching the executables of the IPA
+func (s *SymbolService) UploadDSYMs(ctx context.Context, ipa Source, root string) (*DSYMReport, error) {
+	uuids, err := inspect.IPAUUIDs(ipa, ipa.Size())
+	if err != nil {
+		return nil, NewAppCenterError(SymbolUploadError, err)
+	}
+
+	report, dsyms, err := FindDSYMs(root, uuids, s.client.logger(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dsyms) > 0 {
+		src, err := zipDirectories(strings.TrimSuffix(ipa.Name(), filepath.Ext(ipa.Name()))+".dSYM.zip", dsyms...)
+		if err != nil {
+			return report, NewAppCenterError(SymbolUploadError, err)
+		}
+		defer src.Close()
+
+		report.Upload, err = s.Upload(ctx, SymbolUploadTask{Type: SymbolTypeApple, Source: src})
+		if err != nil {
+			return report, err
+		}
+		report.UploadID = report.Upload.ID
+	}
+
+	renderDSYMReport(report, s.client.UI, s.client.logger(ctx))
+
+	return report, nil
+}
+
+// renderDSYMReport prints the covered and missing UUIDs
+func renderDSYMReport(r *DSYMReport, ui UI, l *zerolog.Logger) {
+	data := [][]string{{"UUID", "dSYM"}}
+
+	uuids := []string{}
+	for u := range r.Covered {
+		uuids = append(uuids, u)
+	}
+	sort.Strings(uuids)
+
+	for _, u := range uuids {
+		data = append(data, []string{u, filepath.Base(r.Covered[u])})
+	}
+
+	for _, u := range r.Missing {
+		data = append(data, []string{u, "MISSING"})
+	}
+
+	ui.Table(data, true)
+
+	if len(r.Missing) > 0 {
+		l.Warn().Strs("UUIDs", r.Missing).Msg(fmt.Sprintf("%d UUID(s) without dSYM", len(r.Missing)))
+	}
+}
@@ -0,0 +1,90 @@
+package appcenter
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// machO encodes a Mach-O executable with a LC_UUID load command
+func machO(uuid byte) []byte {
+	b := make([]byte, 32+24)
+	binary.LittleEndian.PutUint32(b[0:], 0xfeedfacf)
+	binary.LittleEndian.PutUint32(b[4:], 0x0100000c)
+	binary.LittleEndian.PutUint32(b[12:], 2)
+	binary.LittleEndian.PutUint32(b[16:], 1)
+	binary.LittleEndian.PutUint32(b[20:], 24)
+	binary.LittleEndian.PutUint32(b[32:], 0x1b)
+	binary.LittleEndian.PutUint32(b[36:], 24)
+	b[55] = uuid
+	return b
+}
+
+func writeDSYM(t *testing.T, dir string, name string, uuid byte) {
+	dwarf := filepath.Join(dir, name, "Contents", "Resources", "DWARF")
+	assert.NoError(t, os.MkdirAll(dwarf, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dwarf, "App"), machO(uuid), 0644))
+}
+
+func TestUploadDSYMs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "appcenter")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeDSYM(t, filepath.Join(dir, "dSYMs"), "App.app.dSYM", 1)
+	writeDSYM(t, filepath.Join(dir, "dSYMs"), "Other.app.dSYM", 3)
+
+	var ipa bytes.Buffer
+	w := zip.NewWriter(&ipa)
+	for name, data := range map[string][]byte{
+		"Payload/App.app/Info.plist": []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict><key>CFBundleExecutable</key><string>App</string></dict></plist>`),
+		"Payload/App.app/App": machO(1),
+	} {
+		f, err := w.Create(name)
+		assert.NoError(t, err)
+		_, err = f.Write(data)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+
+	t.Run("Matching dSYMs are found", func(t *testing.T) {
+		report, dsyms, err := FindDSYMs(dir, []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"}, &log.Logger)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(dir, "dSYMs", "App.app.dSYM")}, dsyms)
+		assert.Equal(t, map[string]string{"00000000-0000-0000-0000-000000000001": dsyms[0]}, report.Covered)
+		assert.Equal(t, []string{"00000000-0000-0000-0000-000000000002"}, report.Missing)
+	})
+
+	t.Run("Matching dSYMs are uploaded", func(t *testing.T) {
+		c, s, done := newSymbolsServer(t)
+		defer done()
+
+		report, err := c.Symbols.UploadDSYMs(context.Background(), &bytesSource{bytes.NewReader(ipa.Bytes()), "App.ipa"}, dir)
+		assert.NoError(t, err)
+		assert.Empty(t, report.Missing)
+		assert.Equal(t, "upload-id", report.Upload.ID)
+		assert.Equal(t, "upload-id", report.UploadID)
+
+		assert.Equal(t, symbolUploadBody{SymbolType: SymbolTypeApple, FileName: "App.dSYM.zip"}, s.begin)
+		assert.Equal(t, SymbolUploadCommitted, s.status)
+
+		z, err := zip.NewReader(bytes.NewReader(s.blob), int64(len(s.blob)))
+		assert.NoError(t, err)
+		names := []string{}
+		for _, f := range z.File {
+			names = append(names, f.Name)
+		}
+		sort.Strings(names)
+		assert.Equal(t, []string{"App.app.dSYM/Contents/Resources/DWARF/App"}, names)
+	})
+}
@@ -0,0 +1,229 @@
+package appcenter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+const (
+	// SymbolUploadCreated the upload session was started but never committed nor aborted
+	SymbolUploadCreated = "created"
+
+	// SymbolUploadCommitted the symbols were uploaded and are waiting to be processed
+	SymbolUploadCommitted = "committed"
+
+	// SymbolUploadAborted the upload session was aborted
+	SymbolUploadAborted = "aborted"
+
+	// SymbolUploadProcessing the symbols are being processed
+	SymbolUploadProcessing = "processing"
+
+	// SymbolUploadIndexed the symbols are processed and used to symbolicate the crashes
+	SymbolUploadIndexed = "indexed"
+
+	// SymbolUploadFailed the processing of the symbols failed
+	SymbolUploadFailed = "failed"
+)
+
+// UploadedSymbol is a symbol file of a symbol upload
+type UploadedSymbol struct {
+	ID       string `json:"symbol_id"`
+	Platform string `json:"platform,omitempty"`
+}
+
+// SymbolUploadDetails is a symbol upload as returned by the symbol uploads listing
+type SymbolUploadDetails struct {
+	ID              string           `json:"symbol_upload_id"`
+	Status          string           `json:"status"`
+	SymbolType      string           `json:"symbol_type"`
+	FileName        string           `json:"file_name,omitempty"`
+	FileSize        int64            `json:"file_size,omitempty"`
+	Origin          string           `json:"origin,omitempty"`
+	Timestamp       string           `json:"timestamp,omitempty"`
+	SymbolsUploaded []UploadedSymbol `json:"symbols_uploaded,omitempty"`
+}
+
+// MissingSymbol is a symbol required to symbolicate the crashes of a symbol group
+type MissingSymbol struct {
+	ID     string `json:"symbol_id"`
+	Name   string `json:"name,omitempty"`
+	CodeID string `json:"code_id,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// MissingSymbolGroup is a group of crashes that can not be symbolicated because of missing
+// symbols
+type MissingSymbolGroup struct {
+	ID             string          `json:"symbol_group_id"`
+	CrashCount     int             `json:"crash_count"`
+	AppVersion     string          `json:"app_ver,omitempty"`
+	AppBuild       string          `json:"app_build,omitempty"`
+	Status         string          `json:"status,omitempty"`
+	LastModified   string          `json:"last_modified,omitempty"`
+	MissingSymbols []MissingSymbol `json:"missing_symbols,omitempty"`
+}
+
+// symbolGroupPages is the pagination of the symbol groups, listed under symbol_groups
+var symbolGroupPages = pageFormat{top: "top", skip: "skip", items: "symbol_groups"}
+
+// StaleSymbolUploads describes the symbol uploads to delete when pruning the symbol uploads of an
+// application
+type StaleSymbolUploads struct {
+	// Statuses are the statuses of the uploads to delete
+	Statuses []string
+
+	// OlderThan only deletes the uploads started more than this duration ago
+	OlderThan time.Duration
+}
+
+// DefaultStaleStatuses are the statuses of the uploads that will never be used to symbolicate
+// crashes
+var DefaultStaleStatuses = []string{SymbolUploadCreated, SymbolUploadAborted, SymbolUploadFailed}
+
+// Apply returns the stale symbol uploads, from the oldest to the most recent one
+func (p StaleSymbolUploads) Apply(uploads []SymbolUploadDetails, now time.Time) []SymbolUploadDetails {
+	res := []SymbolUploadDetails{}
+
+	for _, u := range uploads {
+		if !p.matchesStatus(u.Status) {
+			continue
+		}
+
+		if p.OlderThan > 0 {
+			started, err := time.Parse(time.RFC3339, u.Timestamp)
+			// an upload with an unknown date is never pruned on age
+			if err != nil || now.Sub(started) < p.OlderThan {
+				continue
+			}
+		}
+
+		res = append(res, u)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Timestamp < res[j].Timestamp
+	})
+
+	return res
+}
+
+func (p StaleSymbolUploads) matchesStatus(status string) bool {
+	statuses := p.Statuses
+	if len(statuses) == 0 {
+		statuses = DefaultStaleStatuses
+	}
+
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// List returns the symbol uploads of the application, restricted to the provided status if any
+func (s *SymbolService) List(ctx context.Context, status string) ([]SymbolUploadDetails, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", status)
+	}
+
+	res := []SymbolUploadDetails{}
+	err := s.client.paginate(ctx, "symbol_uploads", query, func(item json.RawMessage) error {
+		var u SymbolUploadDetails
+		if err := json.Unmarshal(item, &u); err != nil {
+			return err
+		}
+
+		res = append(res, u)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Delete deletes the symbol upload with the provided identifier, along with its symbols
+func (s *SymbolService) Delete(ctx context.Context, id string) error {
+	return s.client.NewAPIRequest(ctx, http.MethodDelete, fmt.Sprintf("symbol_uploads/%v", id), nil, nil)
+}
+
+// Prune deletes the provided symbol uploads
+func (s *SymbolService) Prune(ctx context.Context, uploads []SymbolUploadDetails) error {
+	sp, err := s.client.UI.Start("Deleting symbol uploads")
+	if err != nil {
+		return err
+	}
+
+	for i, u := range uploads {
+		sp.UpdateText(fmt.Sprintf("Deleting symbol upload %v (%d/%d)", u.ID, i+1, len(uploads)))
+		if err := s.Delete(ctx, u.ID); err != nil {
+			sp.Fail(fmt.Sprintf("Failed to delete symbol upload %v", u.ID))
+			return err
+		}
+	}
+
+	sp.Success(fmt.Sprintf("%d symbol uploads deleted", len(uploads)))
+
+	return nil
+}
+
+// Missing returns the active groups of crashes that can not be symbolicated because of missing
+// symbols
+func (s *SymbolService) Missing(ctx context.Context) ([]MissingSymbolGroup, error) {
+	query := url.Values{}
+	query.Set("status", "active")
+
+	res := []MissingSymbolGroup{}
+	err := s.client.paginatePages(ctx, "diagnostics/symbol_groups", query, symbolGroupPages, func(item json.RawMessage) error {
+		var g MissingSymbolGroup
+		if err := json.Unmarshal(item, &g); err != nil {
+			return err
+		}
+
+		res = append(res, g)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Ignore marks the symbols as ignored, the crashes requiring them are no longer reported as
+// missing symbols
+func (s *SymbolService) Ignore(ctx context.Context, ids []string) error {
+	sp, err := s.client.UI.Start("Ignoring symbols")
+	if err != nil {
+		return err
+	}
+
+	for i, id := range ids {
+		sp.UpdateText(fmt.Sprintf("Ignoring symbol %v (%d/%d)", id, i+1, len(ids)))
+		if err := s.client.NewAPIRequest(
+			ctx,
+			http.MethodPost,
+			fmt.Sprintf("diagnostics/symbols/%v/ignore", url.PathEscape(id)),
+			nil,
+			nil,
+		); err != nil {
+			sp.Fail(fmt.Sprintf("Failed to ignore symbol %v", id))
+			return err
+		}
+	}
+
+	sp.Success(fmt.Sprintf("%d symbols ignored", len(ids)))
+
+	return nil
+}
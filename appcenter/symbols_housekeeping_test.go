@@ -0,0 +1,119 @@
+package appcenter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleSymbolUploads(t *testing.T) {
+	now := time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC)
+
+	uploads := []SymbolUploadDetails{
+		{ID: "indexed", Status: SymbolUploadIndexed, Timestamp: "2020-11-01T00:00:00Z"},
+		{ID: "failed", Status: SymbolUploadFailed, Timestamp: "2020-12-10T00:00:00Z"},
+		{ID: "created", Status: SymbolUploadCreated, Timestamp: "2020-11-02T00:00:00Z"},
+		{ID: "aborted", Status: SymbolUploadAborted},
+	}
+
+	ids := func(uploads []SymbolUploadDetails) []string {
+		res := []string{}
+		for _, u := range uploads {
+			res = append(res, u.ID)
+		}
+		return res
+	}
+
+	testCases := []struct {
+		name   string
+		policy StaleSymbolUploads
+		stale  []string
+	}{
+		{"Default statuses", StaleSymbolUploads{}, []string{"aborted", "created", "failed"}},
+		{"Statuses", StaleSymbolUploads{Statuses: []string{SymbolUploadIndexed}}, []string{"indexed"}},
+		{"Older than", StaleSymbolUploads{OlderThan: 10 * 24 * time.Hour}, []string{"created"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.stale, ids(tc.policy.Apply(uploads, now)))
+		})
+	}
+}
+
+func TestSymbolHousekeeping(t *testing.T) {
+	var deleted, ignored []string
+
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/apps/owner/app/symbol_uploads":
+			assert.Equal(t, SymbolUploadFailed, r.URL.Query().Get("status"))
+			assert.NoError(t, json.NewEncoder(w).Encode([]SymbolUploadDetails{
+				{ID: "a", Status: SymbolUploadFailed, SymbolType: SymbolTypeApple},
+			}))
+
+		case r.Method == http.MethodDelete && r.URL.Path == "/apps/owner/app/symbol_uploads/a":
+			deleted = append(deleted, "a")
+
+		case r.Method == http.MethodGet && r.URL.Path == "/apps/owner/app/diagnostics/symbol_groups":
+			assert.Equal(t, "active", r.URL.Query().Get("status"))
+			w.Write([]byte(`{"symbol_groups": [{"symbol_group_id": "g", "crash_count": 3, "app_ver": "1.2.3",
+				"missing_symbols": [{"symbol_id": "ABCD", "name": "App", "status": "missing"}]}],
+				"total_crash_count": 3}`))
+
+		case r.Method == http.MethodPost && r.URL.Path == "/apps/owner/app/diagnostics/symbols/ABCD/ignore":
+			ignored = append(ignored, "ABCD")
+
+		default:
+			t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+		}
+	})
+	defer done()
+
+	ctx := context.Background()
+
+	uploads, err := c.Symbols.List(ctx, SymbolUploadFailed)
+	assert.NoError(t, err)
+	assert.Equal(t, []SymbolUploadDetails{{ID: "a", Status: SymbolUploadFailed, SymbolType: SymbolTypeApple}}, uploads)
+
+	assert.NoError(t, c.Symbols.Prune(ctx, uploads))
+	assert.Equal(t, []string{"a"}, deleted)
+
+	groups, err := c.Symbols.Missing(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, groups, 1)
+	assert.Equal(t, 3, groups[0].CrashCount)
+	assert.Equal(t, []MissingSymbol{{ID: "ABCD", Name: "App", Status: "missing"}}, groups[0].MissingSymbols)
+
+	assert.NoError(t, c.Symbols.Ignore(ctx, []string{"ABCD"}))
+	assert.Equal(t, []string{"ABCD"}, ignored)
+}
+
+func TestMissingSymbolsPagination(t *testing.T) {
+	requests := 0
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/apps/owner/app/diagnostics/symbol_groups", r.URL.Path)
+		assert.Equal(t, "active", r.URL.Query().Get("status"))
+		top, _ := strconv.Atoi(r.URL.Query().Get("top"))
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+
+		groups := []MissingSymbolGroup{}
+		for i := skip; i < skip+top && i < 150; i++ {
+			groups = append(groups, MissingSymbolGroup{ID: strconv.Itoa(i)})
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"symbol_groups": groups}))
+	})
+	defer done()
+
+	groups, err := c.Symbols.Missing(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, groups, 150)
+	assert.Equal(t, "149", groups[149].ID)
+	assert.Equal(t, 2, requests)
+}
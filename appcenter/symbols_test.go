@@ -0,0 +1,190 @@
+package appcenter
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// symbolsServer serves the symbol upload endpoints and stores the uploaded blob
+type symbolsServer struct {
+	sync.Mutex
+	t      *testing.T
+	url    string
+	begin  symbolUploadBody
+	blocks map[string][]byte
+	blob   []byte
+	status string
+	// blockStatus is the status of the block uploads, when they fail
+	blockStatus   int
+	blockAttempts int
+}
+
+func (s *symbolsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/apps/owner/app/symbol_uploads":
+		assert.NoError(s.t, json.NewDecoder(r.Body).Decode(&s.begin))
+		assert.NoError(s.t, json.NewEncoder(w).Encode(SymbolUpload{ID: "upload-id", UploadURL: s.url + "/blob?sig=abc"}))
+
+	case r.Method == http.MethodPut && r.URL.Path == "/blob":
+		assert.Equal(s.t, "abc", r.URL.Query().Get("sig"))
+		data, err := ioutil.ReadAll(r.Body)
+		assert.NoError(s.t, err)
+
+		if r.URL.Query().Get("comp") == "block" {
+			s.blockAttempts++
+			if s.blockStatus != 0 {
+				w.WriteHeader(s.blockStatus)
+				return
+			}
+			s.blocks[r.URL.Query().Get("blockid")] = data
+		} else {
+			var list struct {
+				Latest []string `xml:"Latest"`
+			}
+			assert.NoError(s.t, xml.Unmarshal(data, &list))
+			for _, id := range list.Latest {
+				s.blob = append(s.blob, s.blocks[id]...)
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPatch && r.URL.Path == "/apps/owner/app/symbol_uploads/upload-id":
+		var body symbolUploadStatusBody
+		assert.NoError(s.t, json.NewDecoder(r.Body).Decode(&body))
+		s.status = body.Status
+
+	default:
+		s.t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+	}
+}
+
+// bytesSource is an in memory Source
+type bytesSource struct {
+	*bytes.Reader
+	name string
+}
+
+func (s *bytesSource) Name() string {
+	return s.name
+}
+
+func (s *bytesSource) Close() error {
+	return nil
+}
+
+func newSymbolsServer(t *testing.T) (*Client, *symbolsServer, func()) {
+	s := &symbolsServer{t: t, blocks: map[string][]byte{}}
+	c, done := newTestClient(t, s.ServeHTTP)
+	s.url = c.BaseURL.String()
+	return c, s, done
+}
+
+func TestSymbolUpload(t *testing.T) {
+	defer func(size int64) { blobBlockSize = size }(blobBlockSize)
+	blobBlockSize = 4
+
+	dir, err := ioutil.TempDir("", "appcenter")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("Android mappings", func(t *testing.T) {
+		c, s, done := newSymbolsServer(t)
+		defer done()
+
+		mapping := filepath.Join(dir, "mapping.txt")
+		assert.NoError(t, ioutil.WriteFile(mapping, []byte("com.test.A -> a:"), 0644))
+
+		_, err := c.Symbols.Upload(context.Background(), SymbolUploadTask{Type: SymbolTypeAndroidProguard, FilePath: mapping})
+		assert.Error(t, err)
+
+		upload, err := c.Symbols.Upload(context.Background(), SymbolUploadTask{
+			Type:     SymbolTypeAndroidProguard,
+			FilePath: mapping,
+			Version:  "1.2.3",
+			Build:    "45",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "upload-id", upload.ID)
+
+		assert.Equal(t, symbolUploadBody{SymbolType: "AndroidProguard", FileName: "mapping.txt", Build: "45", Version: "1.2.3"}, s.begin)
+		assert.Equal(t, "com.test.A -> a:", string(s.blob))
+		assert.Len(t, s.blocks, 4)
+		assert.Equal(t, SymbolUploadCommitted, s.status)
+	})
+
+	t.Run("dSYM bundles are zipped", func(t *testing.T) {
+		c, s, done := newSymbolsServer(t)
+		defer done()
+
+		dsym := filepath.Join(dir, "App.app.dSYM")
+		dwarf := filepath.Join(dsym, "Contents", "Resources", "DWARF")
+		assert.NoError(t, os.MkdirAll(dwarf, 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dwarf, "App"), []byte("dwarf"), 0644))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dsym, "Contents", "Info.plist"), []byte("plist"), 0644))
+
+		_, err := c.Symbols.Upload(context.Background(), SymbolUploadTask{Type: SymbolTypeApple, FilePath: dsym})
+		assert.NoError(t, err)
+		assert.Equal(t, "App.app.dSYM.zip", s.begin.FileName)
+
+		z, err := zip.NewReader(bytes.NewReader(s.blob), int64(len(s.blob)))
+		assert.NoError(t, err)
+
+		names := []string{}
+		for _, f := range z.File {
+			names = append(names, f.Name)
+		}
+		sort.Strings(names)
+		assert.Equal(t, []string{"App.app.dSYM/Contents/Info.plist", "App.app.dSYM/Contents/Resources/DWARF/App"}, names)
+	})
+
+	t.Run("Failed uploads are aborted", func(t *testing.T) {
+		defer func(d time.Duration) { retryDelay = d }(retryDelay)
+		retryDelay = time.Millisecond
+
+		for status, attempts := range map[int]int{
+			http.StatusInternalServerError: retryAttempts,
+			http.StatusTooManyRequests:     retryAttempts,
+			http.StatusForbidden:           1,
+		} {
+			c, s, done := newSymbolsServer(t)
+			s.blockStatus = status
+
+			_, err := c.Symbols.Upload(context.Background(), SymbolUploadTask{
+				Type:   SymbolTypeUWP,
+				Source: &bytesSource{bytes.NewReader([]byte("symbols")), "app.appxsym"},
+			})
+			done()
+
+			assert.Error(t, err)
+			assert.True(t, strings.HasPrefix(err.Error(), "AppCenter error: Symbol upload failed"))
+			assert.Equal(t, SymbolUploadAborted, s.status)
+			assert.Equal(t, attempts, s.blockAttempts, "attempts for status %v", status)
+		}
+	})
+}
+
+func TestParseSymbolType(t *testing.T) {
+	typ, err := ParseSymbolType("dSYM")
+	assert.NoError(t, err)
+	assert.Equal(t, SymbolTypeApple, typ)
+
+	_, err = ParseSymbolType("pdb")
+	assert.Error(t, err)
+}
@@ -0,0 +1,271 @@
+package appcenter
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pterm/pterm"
+)
+
+// UI reports the progress of the operations of the services and prints their details. The
+// services go through it rather than printing directly, so the command line can switch between
+// interactive spinners, plain lines for the logs of the CI systems, or nothing at all.
+type UI interface {
+	// Start reports the start of an operation, ended by one of the methods of the progress
+	Start(text string) (Progress, error)
+
+	// Table prints the rows, the first one being the header if header is true
+	Table(rows [][]string, header bool)
+
+	// Result prints the rows of the result of an operation, printed even by the QuietUI
+	Result(rows [][]string, header bool)
+
+	// Info, Success, Warning and Error print a message outside of an operation
+	Info(text string)
+	Success(text string)
+	Warning(text string)
+	Error(text string)
+}
+
+// Progress is an operation in progress, as reported by a pterm spinner
+type Progress interface {
+	// UpdateText replaces the description of the operation
+	UpdateText(text string)
+
+	// Success ends the operation, with the message or the description of the operation
+	Success(message ...interface{})
+
+	// Fail ends the operation in error
+	Fail(message ...interface{})
+
+	// Warning ends the operation with a warning
+	Warning(message ...interface{})
+}
+
+// DefaultUI is the UI of the new clients
+var DefaultUI UI = PtermUI{}
+
+// PtermUI reports the progress with pterm spinners and prints pterm tables
+type PtermUI struct{}
+
+// Start starts a spinner
+func (PtermUI) Start(text string) (Progress, error) {
+	sp, err := pterm.DefaultSpinner.Start(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return sp, nil
+}
+
+// Table renders a pterm table
+func (PtermUI) Table(rows [][]string, header bool) {
+	t := pterm.DefaultTable.WithData(rows)
+	if header {
+		t = t.WithHasHeader()
+	}
+
+	//nolint:errcheck
+	t.Render()
+}
+
+// Result renders a pterm table
+func (u PtermUI) Result(rows [][]string, header bool) {
+	u.Table(rows, header)
+}
+
+// Info prints a pterm info message
+func (PtermUI) Info(text string) {
+	pterm.Info.Println(text)
+}
+
+// Success prints a pterm success message
+func (PtermUI) Success(text string) {
+	pterm.Success.Println(text)
+}
+
+// Warning prints a pterm warning message
+func (PtermUI) Warning(text string) {
+	pterm.Warning.Println(text)
+}
+
+// Error prints a pterm error message
+func (PtermUI) Error(text string) {
+	pterm.Error.Println(text)
+}
+
+// ptermWriter writes to the output of pterm, so the plain lines follow its redirection
+type ptermWriter struct{}
+
+func (ptermWriter) Write(p []byte) (int, error) {
+	pterm.Print(string(p))
+	return len(p), nil
+}
+
+// PlainUI prints a single timestamped line when an operation starts and when it ends, without
+// the redraw sequences of the spinners which flood the logs when the output is not a terminal
+type PlainUI struct {
+	Out io.Writer
+	Now func() time.Time
+
+	// Prefix starts the description of the operations, ex: the name of the binary when several
+	// ones are uploaded at the same time
+	Prefix string
+}
+
+// NewPlainUI returns a plain UI writing to the writer
+func NewPlainUI(out io.Writer) *PlainUI {
+	return &PlainUI{Out: out, Now: time.Now}
+}
+
+// Start prints the start line of the operation
+func (u *PlainUI) Start(text string) (Progress, error) {
+	u.line("start", text)
+	return &plainProgress{ui: u, text: text}, nil
+}
+
+// Table prints the rows as aligned columns
+func (u *PlainUI) Table(rows [][]string, header bool) {
+	w := tabwriter.NewWriter(u.Out, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		for i, cell := range row {
+			if i > 0 {
+				fmt.Fprint(w, "\t")
+			}
+			fmt.Fprint(w, cell)
+		}
+		fmt.Fprintln(w)
+	}
+
+	//nolint:errcheck
+	w.Flush()
+}
+
+// Result prints the rows as aligned columns
+func (u *PlainUI) Result(rows [][]string, header bool) {
+	u.Table(rows, header)
+}
+
+// Info prints a timestamped info line
+func (u *PlainUI) Info(text string) {
+	u.line("info", text)
+}
+
+// Success prints a timestamped success line
+func (u *PlainUI) Success(text string) {
+	u.line("done", text)
+}
+
+// Warning prints a timestamped warning line
+func (u *PlainUI) Warning(text string) {
+	u.line("warning", text)
+}
+
+// Error prints a timestamped error line
+func (u *PlainUI) Error(text string) {
+	u.line("error", text)
+}
+
+func (u *PlainUI) line(status string, text string) {
+	fmt.Fprintf(u.Out, "%v %-7v %v%v\n", u.Now().Format(time.RFC3339), status, u.Prefix, text)
+}
+
+type plainProgress struct {
+	ui   *PlainUI
+	text string
+}
+
+// UpdateText only keeps the text for the end line, the intermediate states are not printed
+func (p *plainProgress) UpdateText(text string) {
+	p.text = text
+}
+
+func (p *plainProgress) Success(message ...interface{}) {
+	p.ui.line("done", p.message(message))
+}
+
+func (p *plainProgress) Fail(message ...interface{}) {
+	p.ui.line("failed", p.message(message))
+}
+
+func (p *plainProgress) Warning(message ...interface{}) {
+	p.ui.line("warning", p.message(message))
+}
+
+func (p *plainProgress) message(message []interface{}) string {
+	if len(message) > 0 {
+		return fmt.Sprint(message...)
+	}
+
+	return p.text
+}
+
+// concurrentUI returns the UI of one of several operations running at the same time, prefixed
+// with its name. Spinners would overwrite each other, the progress is reported as plain lines
+// on the pterm output instead.
+func concurrentUI(ui UI, name string) UI {
+	switch u := ui.(type) {
+	case PtermUI:
+		return &PlainUI{Out: ptermWriter{}, Now: time.Now, Prefix: fmt.Sprintf("[%v] ", name)}
+	case *PlainUI:
+		return &PlainUI{Out: u.Out, Now: u.Now, Prefix: fmt.Sprintf("%v[%v] ", u.Prefix, name)}
+	}
+
+	return ui
+}
+
+// QuietUI only prints the results of the operations to Out, and the errors to Err. Nothing is
+// printed to a nil writer.
+type QuietUI struct {
+	Out io.Writer
+	Err io.Writer
+}
+
+// Start returns a progress reporting nothing
+func (QuietUI) Start(text string) (Progress, error) {
+	return quietProgress{}, nil
+}
+
+// Table prints nothing
+func (QuietUI) Table(rows [][]string, header bool) {}
+
+// Result renders a pterm table to Out, bypassing the pterm output
+func (u QuietUI) Result(rows [][]string, header bool) {
+	if u.Out == nil {
+		return
+	}
+
+	t := pterm.DefaultTable.WithData(rows)
+	if header {
+		t = t.WithHasHeader()
+	}
+
+	if s, err := t.Srender(); err == nil {
+		fmt.Fprintln(u.Out, s)
+	}
+}
+
+// Info prints nothing
+func (QuietUI) Info(text string) {}
+
+// Success prints nothing
+func (QuietUI) Success(text string) {}
+
+// Warning prints nothing
+func (QuietUI) Warning(text string) {}
+
+// Error prints the message to Err
+func (u QuietUI) Error(text string) {
+	if u.Err != nil {
+		fmt.Fprintln(u.Err, text)
+	}
+}
+
+type quietProgress struct{}
+
+func (quietProgress) UpdateText(text string)         {}
+func (quietProgress) Success(message ...interface{}) {}
+func (quietProgress) Fail(message ...interface{})    {}
+func (quietProgress) Warning(message ...interface{}) {}
@@ -0,0 +1,84 @@
+package appcenter
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlainUI(t *testing.T) {
+	var out bytes.Buffer
+	ui := NewPlainUI(&out)
+	ui.Now = func() time.Time { return time.Date(2020, 5, 4, 12, 30, 0, 0, time.UTC) }
+
+	sp, err := ui.Start("Uploading")
+	assert.NoError(t, err)
+	sp.UpdateText("Uploading 50%")
+	sp.Success()
+
+	sp, err = ui.Start("Committing")
+	assert.NoError(t, err)
+	sp.Fail("Failed to commit")
+
+	ui.Table([][]string{{"Name", "Value"}, {"Version", "1.0"}}, true)
+	ui.Info("Nothing to prune")
+	ui.Warning("Pruning aborted")
+
+	assert.Equal(t, "2020-05-04T12:30:00Z start   Uploading\n"+
+		"2020-05-04T12:30:00Z done    Uploading 50%\n"+
+		"2020-05-04T12:30:00Z start   Committing\n"+
+		"2020-05-04T12:30:00Z failed  Failed to commit\n"+
+		"Name     Value\n"+
+		"Version  1.0\n"+
+		"2020-05-04T12:30:00Z info    Nothing to prune\n"+
+		"2020-05-04T12:30:00Z warning Pruning aborted\n", out.String())
+}
+
+func TestQuietUI(t *testing.T) {
+	var out, errOut bytes.Buffer
+	ui := QuietUI{Out: &out, Err: &errOut}
+
+	sp, err := ui.Start("Uploading")
+	assert.NoError(t, err)
+	sp.UpdateText("Uploading 50%")
+	sp.Warning("Slow upload")
+	sp.Success()
+
+	ui.Table([][]string{{"UUID", "dSYM"}}, true)
+	ui.Info("Nothing to prune")
+	ui.Success("Configuration is valid")
+	ui.Warning("Pruning aborted")
+	assert.Empty(t, out.String())
+	assert.Empty(t, errOut.String())
+
+	ui.Result([][]string{{"Version", "1.0"}}, false)
+	assert.Contains(t, out.String(), "Version")
+	assert.Contains(t, out.String(), "1.0", "the results are printed")
+
+	ui.Error("Invalid target")
+	assert.Equal(t, "Invalid target\n", errOut.String(), "the errors are printed")
+
+	// the results of a quiet UI without output are dropped
+	QuietUI{}.Result([][]string{{"Version", "1.0"}}, false)
+	QuietUI{}.Error("Invalid target")
+}
+
+func TestConcurrentUI(t *testing.T) {
+	ui, ok := concurrentUI(PtermUI{}, "app.apk").(*PlainUI)
+	assert.True(t, ok, "spinners are replaced by plain lines")
+	assert.Equal(t, "[app.apk] ", ui.Prefix)
+
+	var out bytes.Buffer
+	plain := NewPlainUI(&out)
+	plain.Now = func() time.Time { return time.Date(2020, 5, 4, 12, 30, 0, 0, time.UTC) }
+
+	sp, err := concurrentUI(plain, "app.ipa").Start("Uploading")
+	assert.NoError(t, err)
+	sp.Success()
+	assert.Equal(t, "2020-05-04T12:30:00Z start   [app.ipa] Uploading\n"+
+		"2020-05-04T12:30:00Z done    [app.ipa] Uploading\n", out.String())
+
+	assert.Equal(t, QuietUI{}, concurrentUI(QuietUI{}, "app.apk"))
+}
@@ -0,0 +1,240 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// uploadExtensions are the extensions of the binaries looked up in directories
+var uploadExtensions = map[string]bool{
+	"aab": true, "apk": true, "appx": true, "appxbundle": true, "appxupload": true, "dmg": true,
+	"ipa": true, "msi": true, "msix": true, "msixbundle": true, "msixupload": true, "pkg": true,
+}
+
+// BatchResult is the result of the upload of one artifact of a batch
+type BatchResult struct {
+	Task      UploadTask
+	ReleaseID int64
+	Summary   *UploadSummary
+	Duration  time.Duration
+	Err       error
+}
+
+// BatchManifest maps artifacts to the application they are uploaded to and to their
+// destinations
+type BatchManifest struct {
+	Artifacts []BatchArtifact `yaml:"artifacts" json:"artifacts"`
+}
+
+// BatchArtifact is an entry of a BatchManifest. File can be a path, a glob or a directory
+// relative to the manifest, the other values default to the ones of the command line.
+type BatchArtifact struct {
+	File         string   `yaml:"file" json:"file"`
+	OwnerName    string   `yaml:"ownerName" json:"ownerName"`
+	AppName      string   `yaml:"appName" json:"appName"`
+	BuildVersion string   `yaml:"buildVersion" json:"buildVersion"`
+	BuildNumber  string   `yaml:"buildNumber" json:"buildNumber"`
+	GroupNames   []string `yaml:"groupName" json:"groupName"`
+	Testers      []string `yaml:"tester" json:"tester"`
+	StoreNames   []string `yaml:"storeName" json:"storeName"`
+	Mandatory    *bool    `yaml:"mandatory" json:"mandatory"`
+	Notify       *bool    `yaml:"notify" json:"notify"`
+}
+
+// ExpandArtifacts resolves the provided paths, globs and directories into the list of the files
+// to upload. Directories are looked up (not recursively) for the supported binary formats, and
+// remote sources (see OpenSource) are kept as is.
+func ExpandArtifacts(patterns []string) ([]string, error) {
+	files := []string{}
+	seen := map[string]bool{}
+
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	for _, pattern := range patterns {
+		if isRemote(pattern) {
+			add(pattern)
+			continue
+		}
+
+		matches := []string{pattern}
+		if strings.ContainsAny(pattern, "*?[") {
+			var err error
+			if matches, err = filepath.Glob(pattern); err != nil {
+				return nil, NewAppCenterError(InputFileError, err)
+			}
+
+			if len(matches) == 0 {
+				return nil, NewAppCenterError(InputFileError, fmt.Errorf("No file matching `%v`", pattern))
+			}
+		}
+
+		for _, m := range matches {
+			fi, err := os.Stat(m)
+			if err != nil || !fi.IsDir() {
+				// missing files are reported by the upload validation
+				add(m)
+				continue
+			}
+
+			entries, err := ioutil.ReadDir(m)
+			if err != nil {
+				return nil, NewAppCenterError(InputFileError, err)
+			}
+
+			found := false
+			for _, e := range entries {
+				ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(e.Name()), "."))
+				if !e.IsDir() && uploadExtensions[ext] {
+					add(filepath.Join(m, e.Name()))
+					found = true
+				}
+			}
+
+			if !found {
+				return nil, NewAppCenterError(InputFileError, fmt.Errorf("No binary found in `%v`", m))
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// LoadBatchManifest reads a YAML or JSON manifest, and returns the upload tasks of its artifacts.
+// The values missing from the artifacts are taken from the provided task.
+func LoadBatchManifest(path string, defaults UploadTask) ([]UploadTask, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, NewAppCenterError(InputFileError, err)
+	}
+
+	var m BatchManifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, NewAppCenterError(InputFileError, fmt.Errorf("Invalid manifest `%v`: %v", path, err))
+	}
+
+	tasks := []UploadTask{}
+	for i, a := range m.Artifacts {
+		if a.File == "" {
+			return nil, NewAppCenterError(InputFileError, fmt.Errorf("Missing file for the artifact #%d of `%v`", i+1, path))
+		}
+
+		pattern := a.File
+		if !filepath.IsAbs(pattern) && !isRemote(pattern) {
+			pattern = filepath.Join(filepath.Dir(path), pattern)
+		}
+
+		files, err := ExpandArtifacts([]string{pattern})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range files {
+			tasks = append(tasks, a.task(f, defaults))
+		}
+	}
+
+	return tasks, nil
+}
+
+func (a BatchArtifact) task(file string, defaults UploadTask) UploadTask {
+	t := defaults
+	t.FilePath = file
+
+	override := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	override(&t.OwnerName, a.OwnerName)
+	override(&t.AppName, a.AppName)
+	override(&t.Option.BuildVersion, a.BuildVersion)
+	override(&t.Option.BuildNumber, a.BuildNumber)
+
+	if len(a.GroupNames) > 0 || len(a.Testers) > 0 || len(a.StoreNames) > 0 {
+		t.Distribute.GroupNames = a.GroupNames
+		t.Distribute.Testers = a.Testers
+		t.Distribute.StoreNames = a.StoreNames
+	}
+
+	if a.Mandatory != nil {
+		t.Distribute.MandatoryUpdate = *a.Mandatory
+	}
+
+	if a.Notify != nil {
+		t.Distribute.NotifyTesters = *a.Notify
+	}
+
+	return t
+}
+
+// UploadBatch uploads and distributes the provided artifacts, running at most parallelism
+// pipelines at a time. The results are in the order of the tasks. The progress of the concurrent
+// pipelines is reported as plain lines prefixed with the name of the binary.
+func (c *Client) UploadBatch(ctx context.Context, tasks []UploadTask, parallelism int) []BatchResult {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	results := make([]BatchResult, len(tasks))
+	jobs := make(chan int, len(tasks))
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				client := c
+				if parallelism > 1 && len(tasks) > 1 {
+					client = c.WithUI(concurrentUI(c.UI, tasks[j].name()))
+				}
+				results[j] = client.uploadTask(ctx, tasks[j])
+			}
+		}()
+	}
+
+	for i := range tasks {
+		jobs <- i
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return results
+}
+
+func (c *Client) uploadTask(ctx context.Context, t UploadTask) (res BatchResult) {
+	res = BatchResult{Task: t, ReleaseID: -1, Summary: newUploadSummary()}
+	start := time.Now()
+	defer func() {
+		res.Duration = time.Since(start)
+	}()
+
+	if t.OwnerName == "" || t.AppName == "" {
+		res.Err = NewAppCenterError(InputFileError, fmt.Errorf("Missing owner or application name for `%v`", t.FilePath))
+		res.Summary.Error = res.Err.Error()
+		return res
+	}
+
+	client := c.WithApp(t.OwnerName, t.AppName)
+
+	res.Summary, res.Err = client.Upload.Run(ctx, t)
+	res.ReleaseID = res.Summary.ReleaseID
+
+	return res
+}
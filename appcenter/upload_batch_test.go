@@ -0,0 +1,121 @@
+package appcenter
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandArtifacts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "appcenter")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"free.apk", "paid.apk", "app.ipa", "notes.txt", "symbols.zip"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte{}, 0644))
+	}
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "empty"), 0755))
+
+	files, err := ExpandArtifacts([]string{dir})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "app.ipa"),
+		filepath.Join(dir, "free.apk"),
+		filepath.Join(dir, "paid.apk"),
+	}, files)
+
+	files, err = ExpandArtifacts([]string{filepath.Join(dir, "*.apk"), filepath.Join(dir, "free.apk"), filepath.Join(dir, "symbols.zip")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "free.apk"),
+		filepath.Join(dir, "paid.apk"),
+		filepath.Join(dir, "symbols.zip"),
+	}, files)
+
+	files, err = ExpandArtifacts([]string{"https://example.com/app.apk?sig=a*b"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/app.apk?sig=a*b"}, files)
+
+	_, err = ExpandArtifacts([]string{filepath.Join(dir, "*.aab")})
+	assert.Error(t, err)
+
+	_, err = ExpandArtifacts([]string{filepath.Join(dir, "empty")})
+	assert.Error(t, err)
+}
+
+func TestLoadBatchManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "appcenter")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"free.apk", "paid.apk"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte{}, 0644))
+	}
+
+	manifest := filepath.Join(dir, "manifest.yml")
+	assert.NoError(t, ioutil.WriteFile(manifest, []byte(`
+artifacts:
+  - file: free.apk
+    appName: app-free
+    groupName: [Beta]
+    notify: true
+  - file: paid.apk
+    ownerName: other
+    appName: app-paid
+    buildVersion: 1.2.3
+`), 0644))
+
+	defaults := UploadTask{
+		OwnerName:  "owner",
+		Distribute: DistributionPayload{GroupNames: []string{"QA"}, MandatoryUpdate: true},
+	}
+
+	tasks, err := LoadBatchManifest(manifest, defaults)
+	assert.NoError(t, err)
+	assert.Equal(t, []UploadTask{
+		{
+			OwnerName:  "owner",
+			AppName:    "app-free",
+			FilePath:   filepath.Join(dir, "free.apk"),
+			Distribute: DistributionPayload{GroupNames: []string{"Beta"}, MandatoryUpdate: true, NotifyTesters: true},
+		},
+		{
+			OwnerName:  "other",
+			AppName:    "app-paid",
+			FilePath:   filepath.Join(dir, "paid.apk"),
+			Distribute: DistributionPayload{GroupNames: []string{"QA"}, MandatoryUpdate: true},
+			Option:     ReleaseUploadPayload{BuildVersion: "1.2.3"},
+		},
+	}, tasks)
+
+	t.Run("JSON manifests are supported", func(t *testing.T) {
+		assert.NoError(t, ioutil.WriteFile(manifest, []byte(`{"artifacts": [{"file": "*.apk", "appName": "app"}]}`), 0644))
+
+		tasks, err := LoadBatchManifest(manifest, defaults)
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 2)
+	})
+}
+
+func TestUploadBatch(t *testing.T) {
+	c := NewClient("api-key")
+
+	tasks := []UploadTask{
+		{OwnerName: "owner", AppName: "app", FilePath: "missing.apk"},
+		{AppName: "app", FilePath: "app.apk"},
+		{OwnerName: "owner", AppName: "app", FilePath: "missing.ipa"},
+	}
+
+	results := c.UploadBatch(context.Background(), tasks, 2)
+	assert.Len(t, results, 3)
+	for i, r := range results {
+		assert.Equal(t, tasks[i], r.Task)
+		assert.Equal(t, int64(-1), r.ReleaseID)
+		assert.Error(t, r.Err)
+	}
+	assert.EqualError(t, results[1].Err, "AppCenter error: Input file error (Missing owner or application name for `app.apk`)")
+}
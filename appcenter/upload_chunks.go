@@ -10,7 +10,6 @@ import (
 	"runtime"
 	"sync"
 
-	"github.com/pterm/pterm"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -44,7 +43,7 @@ func (s *UploadService) UploadChunks(
 	fileSize int64,
 	contentType string,
 ) error {
-	sp, err := pterm.DefaultSpinner.Start("Uploading chunks")
+	sp, err := s.client.UI.Start("Uploading chunks")
 	if err != nil {
 		return err
 	}
@@ -54,7 +53,10 @@ func (s *UploadService) UploadChunks(
 	jobc := make(chan Chunk, chunkCount)
 	g, ctx := errgroup.WithContext(ctx)
 
+	// the workers are counted before starting, so the wait below does not return before they
+	// have scheduled all the chunk uploads
 	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
 		go s.chunkUploadWorker(ctx, i, &wg, jobc, g)
 	}
 
@@ -81,8 +83,8 @@ func (s *UploadService) UploadChunks(
 
 		c.Data = make([]byte, end-start)
 
-		// Reading the bytes
-		if _, err := reader.Read(c.Data); err != nil {
+		// Reading the bytes, the remote sources may return less than a chunk per read
+		if _, err := io.ReadFull(reader, c.Data); err != nil {
 			return NewAppCenterError(ChunkingError, err)
 		}
 
@@ -110,29 +112,33 @@ func (s *UploadService) chunkUploadWorker(
 	jobs <-chan Chunk,
 	g *errgroup.Group,
 ) {
-	wg.Add(1)
 	defer wg.Done()
 
 	for j := range jobs {
+		j := j
 		g.Go(func() error {
-
-			r := chunkUploadResponse{}
-
-			resp, err := s.client.simpleRequest(
-				ctx,
-				http.MethodPost,
-				j.URL,
-				bytes.NewBuffer(j.Data),
-				&r,
-			)
-
-			if err != nil {
-				return err
-			} else if resp.StatusError != nil {
-				return resp.StatusError
-			}
-
-			return nil
+			return retry(ctx, retryAttempts, retryDelay, func() error {
+				r := chunkUploadResponse{}
+
+				resp, err := s.client.simpleRequest(
+					ctx,
+					http.MethodPost,
+					j.URL,
+					bytes.NewBuffer(j.Data),
+					&r,
+				)
+
+				if err != nil {
+					return err
+				} else if resp.StatusError != nil {
+					if !retryableStatus(resp.Response.StatusCode) {
+						return permanent(resp.StatusError)
+					}
+					return resp.StatusError
+				}
+
+				return nil
+			})
 		})
 	}
 }
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-
-	"github.com/pterm/pterm"
 )
 
 // FinishingUploadResponse response definition of the upload finished endpoint
@@ -29,7 +27,7 @@ func (s *UploadService) FinishingUpload(
 	urlEncodedToken string,
 	ID string,
 ) (*FinishingUploadResponse, error) {
-	sp, err := pterm.DefaultSpinner.Start("Completing upload")
+	sp, err := s.client.UI.Start("Completing upload")
 	if err != nil {
 		return nil, err
 	}
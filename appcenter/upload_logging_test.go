@@ -0,0 +1,67 @@
+package appcenter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadLogsCorrelation(t *testing.T) {
+	var domain string
+
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apps/owner/app/uploads/releases":
+			fmt.Fprintf(w, `{"id": "upload-id", "package_asset_id": "asset-id", "upload_domain": "%v"}`, domain)
+		case "/upload/set_metadata/asset-id":
+			fmt.Fprint(w, `{"id": "metadata-id", "chunk_size": 6, "chunk_list": [1]}`)
+		case "/upload/upload_chunk/asset-id", "/upload/finished/asset-id":
+			fmt.Fprint(w, `{"error": false}`)
+		case "/apps/owner/app/uploads/releases/upload-id":
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"code": "Failed", "message": "Failed"}`)
+		default:
+			t.Errorf("Unexpected request to %v", r.URL.Path)
+		}
+	})
+	defer done()
+	domain = c.BaseURL.String()
+
+	var logs bytes.Buffer
+	c = c.WithLogger(zerolog.New(&logs).Level(zerolog.DebugLevel))
+
+	_, err := c.Upload.Run(context.Background(), UploadTask{
+		Source: &bytesSource{bytes.NewReader([]byte("binary")), "app.apk"},
+		Force:  true,
+	})
+	assert.Error(t, err)
+
+	stages := map[string]map[string]interface{}{}
+	scanner := bufio.NewScanner(&logs)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		if line["message"] == "Stage started" {
+			stages[line["Stage"].(string)] = line
+		}
+	}
+
+	stageNames := []string{StageInspect, StageValidate, StageRequest, StageMetadata, StageUpload, StageFinish, StageCommit}
+	assert.Len(t, stages, len(stageNames))
+	for _, stage := range stageNames {
+		assert.Equal(t, "app.apk", stages[stage]["File"], stage)
+	}
+
+	// the upload ID is known once requested
+	assert.Nil(t, stages[StageRequest]["UploadID"])
+	assert.Equal(t, "upload-id", stages[StageMetadata]["UploadID"])
+	assert.Equal(t, "upload-id", stages[StageUpload]["UploadID"])
+	assert.Equal(t, "upload-id", stages[StageCommit]["UploadID"])
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-
-	"github.com/pterm/pterm"
 )
 
 type uploadReleaseBody struct {
@@ -25,7 +23,7 @@ func (s *UploadService) SetMetaData(
 	buildNumber string,
 	buildVersion string,
 ) (*MetadataResponse, error) {
-	sp, err := pterm.DefaultSpinner.Start("Applying meta-data")
+	sp, err := s.client.UI.Start("Applying meta-data")
 	if err != nil {
 		return nil, NewAppCenterError(UploadRequestError, nil)
 	}
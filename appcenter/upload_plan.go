@@ -0,0 +1,204 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"goappcenter/inspect"
+	"net/http"
+	"strings"
+)
+
+// uploadRoles are the roles on an application allowing to upload releases
+var uploadRoles = []string{"manager", "developer"}
+
+// UploadPlan is the result of a dry run: the application, the inspected binary and the steps the
+// upload would perform
+type UploadPlan struct {
+	App         *App          `json:"app"`
+	Info        *inspect.Info `json:"binary,omitempty"`
+	ContentType string        `json:"content_type"`
+	Steps       []string      `json:"steps"`
+}
+
+// Plan performs the checks of the upload without uploading anything: the request and the binary
+// are validated, the application, the role of the API token user and the destinations are
+// checked. It returns the steps the upload would perform.
+func (s *UploadService) Plan(ctx context.Context, r UploadTask) (*UploadPlan, error) {
+	if err := r.validateSource(); err != nil {
+		return nil, NewAppCenterError(InputFileError, err)
+	}
+
+	src := r.Source
+	if src == nil {
+		opened, err := OpenSource(ctx, r.FilePath)
+		if err != nil {
+			return nil, NewAppCenterError(InputFileError, err)
+		}
+		defer opened.Close()
+		src = opened
+	}
+
+	info := r.inspect(src, s.client.logger(ctx))
+
+	if err := r.validateRequest(src); err != nil {
+		return nil, NewAppCenterError(InputFileError, err)
+	}
+
+	app, err := s.checkApp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if info != nil && info.Profile != nil {
+		renderProfile(info.Profile, s.client.UI)
+	}
+
+	if info != nil && !r.Force {
+		if err := s.ValidateBinary(ctx, info, r.Distribute); err != nil {
+			return nil, err
+		}
+	}
+
+	plan := &UploadPlan{App: app, Info: info, ContentType: r.contentType(src, s.client.logger(ctx))}
+
+	plan.Steps = append(plan.Steps,
+		fmt.Sprintf("Upload `%v` (%d bytes, %v) to %v/%v",
+			src.Name(), src.Size(), plan.ContentType, s.client.Config.OwnerName, s.client.Config.AppName),
+		fmt.Sprintf("Create the release %v", r.version()),
+	)
+
+	if r.ReleaseNotes != "" {
+		plan.Steps = append(plan.Steps, "Set the release notes")
+	}
+
+	if r.Symbols != "" {
+		plan.Steps = append(plan.Steps, fmt.Sprintf("Upload the dSYMs found in `%v`", r.Symbols))
+	}
+
+	destinations, err := s.checkDestinations(ctx, r.Distribute)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Steps = append(plan.Steps, destinations...)
+
+	return plan, nil
+}
+
+// version describes the version of the release created by the upload
+func (r UploadTask) version() string {
+	switch {
+	case r.Option.BuildVersion != "" && r.Option.BuildNumber != "":
+		return fmt.Sprintf("%v (%v)", r.Option.BuildVersion, r.Option.BuildNumber)
+	case r.Option.BuildVersion != "":
+		return r.Option.BuildVersion
+	case r.Option.BuildNumber != "":
+		return fmt.Sprintf("(%v)", r.Option.BuildNumber)
+	}
+
+	return "with the version of the binary"
+}
+
+// checkApp checks that the application exists and that the role of the API token user allows to
+// upload releases to it. The scope of the token itself is not exposed by the API: a read-only
+// token of a manager passes the check, and fails at the upload.
+func (s *UploadService) checkApp(ctx context.Context) (*App, error) {
+	name := fmt.Sprintf("%v/%v", s.client.Config.OwnerName, s.client.Config.AppName)
+
+	sp, err := s.client.UI.Start(fmt.Sprintf("Checking the application %v", name))
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := s.client.Apps.Get(ctx)
+	if se, ok := err.(*StatusError); ok && se.StatusCode == http.StatusNotFound {
+		sp.Fail()
+		return nil, NewAppCenterError(ValidationError, fmt.Errorf("Application %v not found", name))
+	} else if err != nil {
+		sp.Fail()
+		return nil, err
+	}
+
+	if len(app.Permissions) == 0 {
+		sp.Warning(fmt.Sprintf("Application %v found, the role of the API token user could not be checked", name))
+		return app, nil
+	}
+
+	for _, role := range app.Permissions {
+		for _, allowed := range uploadRoles {
+			if role == allowed {
+				sp.Success(fmt.Sprintf("Application %v found (role: %v)", name, strings.Join(app.Permissions, ", ")))
+				return app, nil
+			}
+		}
+	}
+
+	sp.Fail()
+	return nil, NewAppCenterError(PermissionError,
+		fmt.Errorf("the role of the API token user on %v is %v, expecting %v", name,
+			strings.Join(app.Permissions, ", "), strings.Join(uploadRoles, " or ")))
+}
+
+// checkDestinations resolves the groups and stores of the payload, and returns the distribution
+// steps
+func (s *UploadService) checkDestinations(ctx context.Context, p DistributionPayload) ([]string, error) {
+	steps := []string{}
+
+	options := []string{}
+	if p.MandatoryUpdate {
+		options = append(options, "mandatory")
+	}
+	if p.NotifyTesters {
+		options = append(options, "testers notified")
+	}
+
+	for _, name := range p.GroupNames {
+		group, err := s.client.Distribute.requestGroup(ctx, name, s.client.Config.OwnerName, s.client.Config.AppName)
+		if err != nil {
+			return nil, NewAppCenterError(DistributionError, fmt.Errorf("group '%v': %w", name, err))
+		}
+
+		details := append([]string{"ID: " + group.ID}, options...)
+		steps = append(steps, step(fmt.Sprintf("Distribute to the group '%v'", name), details))
+	}
+
+	for _, email := range p.Testers {
+		steps = append(steps, step(fmt.Sprintf("Distribute to the tester '%v'", email), options))
+	}
+
+	opts := PublishOptions{Wait: p.WaitForStores, RolloutFraction: p.RolloutFraction}
+	for _, name := range p.StoreNames {
+		store, err := s.client.Stores.Get(ctx, name)
+		if err != nil {
+			return nil, NewAppCenterError(DistributionError, fmt.Errorf("store '%v': %w", name, err))
+		}
+
+		if err := opts.validate(store); err != nil {
+			return nil, NewAppCenterError(ValidationError, err)
+		}
+
+		details := []string{store.Type}
+		if opts.RolloutFraction > 0 {
+			details = append(details, fmt.Sprintf("rolled out to %v%% of the users", opts.RolloutFraction*100))
+		}
+		if opts.Wait {
+			details = append(details, "waiting for the publishing")
+		}
+		steps = append(steps, step(fmt.Sprintf("Publish to the store '%v'", name), details))
+	}
+
+	if len(steps) == 0 {
+		s.client.logger(ctx).Info().Msg("No destination, the release will not be distributed")
+	}
+
+	return steps, nil
+}
+
+// step describes a step of the plan with its details
+func step(action string, details []string) string {
+	if len(details) == 0 {
+		return action
+	}
+
+	return fmt.Sprintf("%v (%v)", action, strings.Join(details, ", "))
+}
@@ -0,0 +1,85 @@
+package appcenter
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadPlan(t *testing.T) {
+	permissions := `["developer"]`
+
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/apps/owner/app":
+			w.Write([]byte(`{"name": "app", "os": "Android", "permissions": ` + permissions + `}`))
+
+		case r.Method == http.MethodGet && r.URL.Path == "/apps/owner/app/distribution_groups/Beta":
+			w.Write([]byte(`{"id": "group-id", "name": "Beta"}`))
+
+		case r.Method == http.MethodGet && r.URL.Path == "/apps/owner/app/distribution_stores/Production":
+			w.Write([]byte(`{"id": "store-id", "name": "Production", "type": "googleplay"}`))
+
+		case r.Method == http.MethodGet && r.URL.Path == "/apps/owner/app/distribution_groups/Unknown":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "NotFound", "message": "Not found"}`))
+
+		default:
+			t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+		}
+	})
+	defer done()
+
+	task := func() UploadTask {
+		return UploadTask{
+			Source: &bytesSource{bytes.NewReader([]byte("binary")), "app.apk"},
+			Option: ReleaseUploadPayload{BuildVersion: "1.2.3", BuildNumber: "45"},
+			Distribute: DistributionPayload{
+				GroupNames:      []string{"Beta"},
+				Testers:         []string{"tester@example.com"},
+				StoreNames:      []string{"Production"},
+				NotifyTesters:   true,
+				RolloutFraction: 0.1,
+			},
+			ReleaseNotes: "Bug fixes",
+		}
+	}
+
+	t.Run("The steps should be planned", func(t *testing.T) {
+		plan, err := c.Upload.Plan(context.Background(), task())
+		assert.NoError(t, err)
+		assert.Equal(t, "app", plan.App.Name)
+		assert.Equal(t, []string{
+			"Upload `app.apk` (6 bytes, application/vnd.android.package-archive) to owner/app",
+			"Create the release 1.2.3 (45)",
+			"Set the release notes",
+			"Distribute to the group 'Beta' (ID: group-id, testers notified)",
+			"Distribute to the tester 'tester@example.com' (testers notified)",
+			"Publish to the store 'Production' (googleplay, rolled out to 10% of the users)",
+		}, plan.Steps)
+	})
+
+	t.Run("Unknown groups should fail", func(t *testing.T) {
+		r := task()
+		r.Distribute.GroupNames = []string{"Unknown"}
+
+		_, err := c.Upload.Plan(context.Background(), r)
+		assert.Equal(t, KindDistribution, KindOf(err))
+	})
+
+	t.Run("Viewers should not be allowed to upload", func(t *testing.T) {
+		permissions = `["viewer"]`
+		defer func() { permissions = `["developer"]` }()
+
+		_, err := c.Upload.Plan(context.Background(), task())
+		assert.Equal(t, KindAuthentication, KindOf(err))
+	})
+
+	t.Run("Missing files should fail", func(t *testing.T) {
+		_, err := c.Upload.Plan(context.Background(), UploadTask{FilePath: "missing.apk"})
+		assert.Equal(t, KindValidation, KindOf(err))
+	})
+}
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"time"
-
-	"github.com/pterm/pterm"
 )
 
 var path string
@@ -20,7 +18,7 @@ type uploadReleaseStatusResponse struct {
 
 // PollForRelease will poll AppCenter till the upload is ready to be pulished
 func (s *UploadService) PollForRelease(ctx context.Context, uploadID string) (int64, error) {
-	sp, err := pterm.DefaultSpinner.Start("Waiting for the release to be published")
+	sp, err := s.client.UI.Start("Waiting for the release to be published")
 	if err != nil {
 		return -1, NewAppCenterError(PollingError, err)
 	}
@@ -43,7 +41,7 @@ func (s *UploadService) PollForRelease(ctx context.Context, uploadID string) (in
 			sp.UpdateText(fmt.Sprintf("Waiting for the release to be published (Try: %d)", count))
 			if count > 60 {
 				sp.Fail()
-				return -1, NewAppCenterError(PollingFailed, nil)
+				return -1, NewAppCenterError(PollingError, nil)
 			}
 
 			// polling for result
@@ -52,6 +50,12 @@ func (s *UploadService) PollForRelease(ctx context.Context, uploadID string) (in
 				sp.Success(fmt.Sprintf("Release is ready to be published (ID: %d)", c))
 				return c, nil
 			}
+
+			// the binary was rejected, polling again would not change it
+			if ae, ok := err.(AppCenterError); ok && ae.msg == ProcessingError {
+				sp.Fail()
+				return -1, err
+			}
 		}
 	}
 
@@ -71,5 +75,9 @@ func (s UploadService) poll(ctx context.Context) (int64, error) {
 		return status.ReleaseDistinctID, nil
 	}
 
+	if status.UploadStatus == "error" {
+		return 0, NewAppCenterError(ProcessingError, fmt.Errorf("%v", status.ErrorDetails))
+	}
+
 	return 0, NewAppCenterError(PollingFailed, nil)
 }
@@ -0,0 +1,33 @@
+package appcenter
+
+import (
+	"fmt"
+	"goappcenter/inspect"
+	"strings"
+)
+
+// renderProfile prints the provisioning profile embedded in the binary
+func renderProfile(p *inspect.ProvisioningProfile, ui UI) {
+	team := p.TeamName
+	if p.TeamID != "" {
+		team = fmt.Sprintf("%v (%v)", p.TeamName, p.TeamID)
+	}
+
+	expiry := "unknown"
+	if !p.ExpirationDate.IsZero() {
+		expiry = p.ExpirationDate.Format("2006-01-02 15:04:05 MST")
+	}
+
+	data := [][]string{
+		{"ProvisioningProfileName", p.Name},
+		{"ProvisioningProfileType", p.Type},
+		{"ProvisioningProfileExpiryDate", expiry},
+		{"Team", team},
+	}
+
+	if len(p.Devices) > 0 {
+		data = append(data, []string{"ProvisionedDevices", strings.Join(p.Devices, ", ")})
+	}
+
+	ui.Table(data, false)
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-
-	"github.com/pterm/pterm"
 )
 
 type commitUploadBody struct {
@@ -21,7 +19,7 @@ type commitReleaseResponse struct {
 }
 
 func (s *UploadService) UploadCommitRelease(ctx context.Context, id string, uploadID string) (*string, error) {
-	sp, err := pterm.DefaultSpinner.Start("Updating status of the release")
+	sp, err := s.client.UI.Start("Updating status of the release")
 	if err != nil {
 		return nil, err
 	}
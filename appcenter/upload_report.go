@@ -0,0 +1,81 @@
+package appcenter
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"goappcenter/inspect"
+	"hash"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// BinaryReport is the description of the uploaded binary, read locally before the upload
+type BinaryReport struct {
+	Info *inspect.Info
+
+	// Fingerprint is the MD5 checksum of the binary, as computed by AppCenter
+	Fingerprint string
+}
+
+// newFingerprint returns the hash computing the fingerprint of the binary. The binary is written
+// to it while its chunks are uploaded, so the remote sources are not downloaded a second time.
+func newFingerprint() hash.Hash {
+	return md5.New()
+}
+
+// newBinaryReport returns the report of the binary, whose data was written to the fingerprint
+func newBinaryReport(info *inspect.Info, fingerprint hash.Hash) *BinaryReport {
+	return &BinaryReport{Info: info, Fingerprint: hex.EncodeToString(fingerprint.Sum(nil))}
+}
+
+// rows returns the local findings to print along with the release details, and warns about the
+// ones not matching the release
+func (b *BinaryReport) rows(res *ReleaseDetails, l *zerolog.Logger) [][]string {
+	data := [][]string{}
+	if b == nil {
+		return data
+	}
+
+	if b.Fingerprint != "" {
+		data = append(data, []string{"LocalFingerprint", b.Fingerprint})
+		if res.Fingerprint != "" && !strings.EqualFold(res.Fingerprint, b.Fingerprint) {
+			l.Warn().
+				Str("Local", b.Fingerprint).
+				Str("Release", res.Fingerprint).
+				Msg("The fingerprint of the release does not match the uploaded binary")
+		}
+	}
+
+	info := b.Info
+	if info == nil || info.Platform != inspect.PlatformAndroid {
+		return data
+	}
+
+	if res.AndroidMinAPILevel != "" && info.MinOS != "" && res.AndroidMinAPILevel != info.MinOS {
+		l.Warn().
+			Str("Local", info.MinOS).
+			Str("Release", res.AndroidMinAPILevel).
+			Msg("The minimum API level of the release does not match the uploaded binary")
+	}
+
+	debuggable := "NO"
+	if info.Debuggable {
+		debuggable = "YES"
+	}
+
+	for _, row := range [][]string{
+		{"MinSdkVersion", info.MinOS},
+		{"TargetSdkVersion", info.TargetSDK},
+		{"ABIs", strings.Join(info.ABIs, ", ")},
+		{"SignatureSchemes", strings.Join(info.SignatureSchemes, ", ")},
+		{"CertificateSHA256", info.CertificateSHA256},
+		{"Debuggable", debuggable},
+	} {
+		if row[1] != "" {
+			data = append(data, row)
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,79 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"goappcenter/inspect"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinaryReport(t *testing.T) {
+	info := &inspect.Info{
+		Platform:          inspect.PlatformAndroid,
+		MinOS:             "21",
+		TargetSDK:         "30",
+		ABIs:              []string{"arm64-v8a", "x86"},
+		SignatureSchemes:  []string{"v1", "v2"},
+		CertificateSHA256: "abcd",
+	}
+
+	fingerprint := newFingerprint()
+	_, err := fingerprint.Write([]byte("binary"))
+	assert.NoError(t, err)
+
+	report := newBinaryReport(info, fingerprint)
+	assert.Equal(t, "9d7183f16acce70658f686ae7f1a4d20", report.Fingerprint)
+
+	assert.Equal(t, [][]string{
+		{"LocalFingerprint", "9d7183f16acce70658f686ae7f1a4d20"},
+		{"MinSdkVersion", "21"},
+		{"TargetSdkVersion", "30"},
+		{"ABIs", "arm64-v8a, x86"},
+		{"SignatureSchemes", "v1, v2"},
+		{"CertificateSHA256", "abcd"},
+		{"Debuggable", "NO"},
+	}, report.rows(&ReleaseDetails{Fingerprint: "9d7183f16acce70658f686ae7f1a4d20", AndroidMinAPILevel: "21"}, &log.Logger))
+
+	t.Run("Binaries which are not inspected only report their fingerprint", func(t *testing.T) {
+		report := &BinaryReport{Fingerprint: "abcd"}
+		assert.Equal(t, [][]string{{"LocalFingerprint", "abcd"}}, report.rows(&ReleaseDetails{}, &log.Logger))
+
+		var none *BinaryReport
+		assert.Empty(t, none.rows(&ReleaseDetails{}, &log.Logger))
+	})
+}
+
+func TestFingerprintWhileUploading(t *testing.T) {
+	var mu sync.Mutex
+	chunks := map[string]string{}
+
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		mu.Lock()
+		chunks[r.URL.Query().Get("block_number")] = string(b)
+		mu.Unlock()
+		fmt.Fprint(w, `{"error": false}`)
+	})
+	defer done()
+
+	// the remote sources may return less than a chunk per read
+	fingerprint := newFingerprint()
+	err := c.Upload.UploadChunks(context.Background(),
+		io.TeeReader(iotest.OneByteReader(strings.NewReader("binary")), fingerprint),
+		c.BaseURL.String(), "upload-id", "asset-id", "token", 4, 2, 6, "application/octet-stream")
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"1": "bina", "2": "ry"}, chunks)
+	assert.Equal(t, "9d7183f16acce70658f686ae7f1a4d20", newBinaryReport(nil, fingerprint).Fingerprint)
+}
@@ -2,8 +2,12 @@ package appcenter
 
 import (
 	"fmt"
+	"goappcenter/inspect"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog"
 )
 
 // UploadTask wrap the required arguments for the upload specifications
@@ -13,20 +17,103 @@ type UploadTask struct {
 	OwnerName  string
 	FilePath   string
 	Distribute DistributionPayload
-	Option     ReleaseUploadPayload
+
+	// Source is the binary to upload, opened from FilePath when nil
+	Source Source
+
+	Option ReleaseUploadPayload
+
+	// ContentType overrides the content type detected from the file
+	ContentType string
+
+	// ReleaseNotes are the release notes set on the release once uploaded
+	ReleaseNotes string
+
+	// Symbols is the directory where the dSYMs of an IPA are looked up, to be uploaded along
+	// with the release
+	Symbols string
+
+	// Force skips the validation of the binary against the application
+	Force bool
 }
 
-func (r UploadTask) validateRequest() error {
+func (r UploadTask) validateRequest(src Source) error {
 	// validation of the sourece file to upload
 	if err := r.validateSource(); err != nil {
 		return err
 	}
 
+	if r.Symbols != "" {
+		if fi, err := os.Stat(r.Symbols); err != nil || !fi.IsDir() {
+			return fmt.Errorf("Symbols directory `%v` does not exist", r.Symbols)
+		}
+	}
+
 	// validation of the request settings
-	return r.validateRequestBuildVersion()
+	return r.validateFormatBuildVersion(inspect.Format(src, src.Size(), src.Name()))
+}
+
+// inspect reads the build version and build number from the binary to upload, if its format
+// supports it, to complete the request. It returns nil if the binary cannot be inspected.
+func (r *UploadTask) inspect(src Source, l *zerolog.Logger) *inspect.Info {
+	info, err := inspect.InspectReader(src, src.Size(), src.Name())
+	if err == inspect.ErrUnsupportedFormat {
+		return nil
+	} else if err != nil {
+		l.Warn().Err(err).Msg("Failed to inspect the binary")
+		return nil
+	}
+
+	r.prefill(info, l)
+	return info
+}
+
+// prefill completes the missing build version and build number of the request with the ones of
+// the binary, and warns when the provided ones do not match the binary
+func (r *UploadTask) prefill(info *inspect.Info, l *zerolog.Logger) {
+	if r.Option.BuildVersion == "" {
+		r.Option.BuildVersion = info.BuildVersion
+	} else if info.BuildVersion != "" && r.Option.BuildVersion != info.BuildVersion {
+		l.Warn().
+			Str("Provided", r.Option.BuildVersion).
+			Str("Binary", info.BuildVersion).
+			Msg("The build version does not match the one of the binary")
+	}
+
+	if r.Option.BuildNumber == "" {
+		r.Option.BuildNumber = info.BuildNumber
+	} else if info.BuildNumber != "" && r.Option.BuildNumber != info.BuildNumber {
+		l.Warn().
+			Str("Provided", r.Option.BuildNumber).
+			Str("Binary", info.BuildNumber).
+			Msg("The build number does not match the one of the binary")
+	}
+}
+
+// contentType returns the content type of the file to upload, unless it is overridden
+func (r UploadTask) contentType(src Source, l *zerolog.Logger) string {
+	if r.ContentType != "" {
+		return r.ContentType
+	}
+
+	return DetectContentType(src, l)
+}
+
+// name returns the file name of the binary to upload
+func (r UploadTask) name() string {
+	if r.Source != nil {
+		return r.Source.Name()
+	}
+
+	return sourceName(r.FilePath)
 }
 
 func (r UploadTask) validateSource() error {
+	// remote sources are validated once opened
+	if r.Source != nil || isRemote(r.FilePath) {
+		return nil
+	}
+
 	_, err := os.Stat(r.FilePath)
 	if os.IsNotExist(err) {
 		return fmt.Errorf("File `%v` does not exsts", r.FilePath)
@@ -34,20 +121,24 @@ func (r UploadTask) validateSource() error {
 	return err
 }
 
+// validateRequestBuildVersion checks the build version and number required by the file extension
 func (r UploadTask) validateRequestBuildVersion() error {
-	ext := filepath.Ext(r.FilePath)
+	return r.validateFormatBuildVersion(strings.TrimPrefix(filepath.Ext(r.name()), "."))
+}
 
+// validateFormatBuildVersion checks the build version and number required by the binary format
+func (r UploadTask) validateFormatBuildVersion(format string) error {
 	if r.Option.BuildNumber == "" || r.Option.BuildVersion == "" {
-		if ext == ".pkg" || ext == ".dmg" {
+		if format == "pkg" || format == "dmg" {
 			return fmt.Errorf("'--build_version' and '--build_number' parameters "+
-				"must be specified to upload file of extension %v", ext)
+				"must be specified to upload file of format %v", format)
 		}
 	}
 
 	if r.Option.BuildVersion == "" {
-		if ext == ".zip" || ext == ".msi" {
+		if format == "zip" || format == "msi" {
 			return fmt.Errorf("'--build_version' parameter must be "+
-				"specified to upload fle of extension '%v'", ext)
+				"specified to upload fle of format '%v'", format)
 		}
 	}
 
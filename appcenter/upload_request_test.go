@@ -1,9 +1,13 @@
 package appcenter
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
+	"goappcenter/inspect"
 	"testing"
 
+	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,4 +62,42 @@ func TestValdationBuildVersionArgument(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("The format should be read from the binary", func(t *testing.T) {
+		var ipa bytes.Buffer
+		w := zip.NewWriter(&ipa)
+		_, err := w.Create("Payload/App.app/Info.plist")
+		assert.NoError(t, err)
+		assert.NoError(t, w.Close())
+
+		// an IPA renamed as a zip does not require the build version, unlike other zip files
+		for _, tc := range []struct {
+			src Source
+			err bool
+		}{
+			{&bytesSource{bytes.NewReader(ipa.Bytes()), "app.zip"}, false},
+			{&bytesSource{bytes.NewReader([]byte("not an archive")), "app.zip"}, true},
+		} {
+			err := UploadTask{Source: tc.src}.validateRequest(tc.src)
+			assert.Equal(t, tc.err, err != nil, tc.src.Name())
+		}
+	})
+}
+
+func TestPrefillFromBinary(t *testing.T) {
+	info := &inspect.Info{BuildVersion: "1.2.3", BuildNumber: "45"}
+
+	t.Run("Missing values should be read from the binary", func(t *testing.T) {
+		r := UploadTask{}
+		r.prefill(info, &log.Logger)
+		assert.Equal(t, "1.2.3", r.Option.BuildVersion)
+		assert.Equal(t, "45", r.Option.BuildNumber)
+	})
+
+	t.Run("Provided values should be kept", func(t *testing.T) {
+		r := UploadTask{Option: ReleaseUploadPayload{BuildVersion: "2.0.0"}}
+		r.prefill(info, &log.Logger)
+		assert.Equal(t, "2.0.0", r.Option.BuildVersion)
+		assert.Equal(t, "45", r.Option.BuildNumber)
+	})
 }
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-
-	"github.com/pterm/pterm"
 )
 
 // UploadResourceResponse response body
@@ -19,7 +17,7 @@ type UploadResourceResponse struct {
 
 // RequestUploadResource will request appcenter for a new resouce assignement ready for upload
 func (s *UploadService) RequestUploadResource(ctx context.Context, r UploadTask) (*UploadResourceResponse, error) {
-	sp, err := pterm.DefaultSpinner.Start("Requesting upload ressource")
+	sp, err := s.client.UI.Start("Requesting upload ressource")
 	if err != nil {
 		return nil, NewAppCenterError(UploadRequestError, nil)
 	}
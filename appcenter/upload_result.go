@@ -2,15 +2,12 @@ package appcenter
 
 import (
 	"context"
-	"fmt"
-	"net/http"
 	"reflect"
 	"strconv"
-
-	"github.com/pterm/pterm"
 )
 
-type releaseInfoResponseBody struct {
+// ReleaseDetails is the full definition of a release
+type ReleaseDetails struct {
 	ID                            int64  `json:"id,omitempty"`
 	AppName                       string `json:"app_name,omitempty"`
 	AppDisplayName                string `json:"app_display_name,omitempty"`
@@ -39,33 +36,29 @@ type releaseInfoResponseBody struct {
 	Enabled                       bool   `json:"enabled,omitempty"`
 	Status                        string `json:"status,omitempty"`
 	IsExternalBuild               bool   `json:"is_external_build,omitempty"`
+
+	Destinations []ReleaseDestination `json:"destinations,omitempty"`
 }
 
-func (s *UploadService) UploadResult(ctx context.Context, id int64) error {
-	sp, err := pterm.DefaultSpinner.Start("Requesting the release details")
+// UploadResult prints and returns the details of the uploaded release, along with the local
+// report of the binary when available. The details are the result of the upload, printed even by
+// the QuietUI.
+func (s *UploadService) UploadResult(ctx context.Context, id int64, report *BinaryReport) (*ReleaseDetails, error) {
+	sp, err := s.client.UI.Start("Requesting the release details")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var res releaseInfoResponseBody
-	path := fmt.Sprintf("releases/%v", id)
-
-	//
-	if err := s.client.NewAPIRequest(
-		ctx,
-		http.MethodGet,
-		path,
-		nil,
-		&res,
-	); err != nil {
+	res, err := s.client.Releases.Get(ctx, id)
+	if err != nil {
 		sp.Fail()
-		return err
+		return nil, err
 	}
 
 	sp.Success()
 
-	fields := reflect.TypeOf(res)
-	values := reflect.ValueOf(res)
+	fields := reflect.TypeOf(*res)
+	values := reflect.ValueOf(*res)
 
 	num := fields.NumField()
 
@@ -96,9 +89,13 @@ func (s *UploadService) UploadResult(ctx context.Context, id int64) error {
 		if val != "" && len(val) < 60 {
 			data = append(data, []string{key, val})
 		}
+
+		if key == "Fingerprint" {
+			data = append(data, report.rows(res, s.client.logger(ctx))...)
+		}
 	}
 
-	pterm.DefaultTable.WithData(data).Render()
+	s.client.UI.Result(data, false)
 
-	return nil
+	return res, nil
 }
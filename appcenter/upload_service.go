@@ -2,8 +2,8 @@ package appcenter
 
 import (
 	"context"
-	"os"
-	"path/filepath"
+	"goappcenter/inspect"
+	"io"
 )
 
 // UploadService definition
@@ -13,7 +13,18 @@ type UploadService struct {
 
 // DistributionPayload upload definition
 type DistributionPayload struct {
-	GroupName string
+	GroupNames      []string
+	Testers         []string
+	StoreNames      []string
+	MandatoryUpdate bool
+	NotifyTesters   bool
+	WaitForStores   bool
+	RolloutFraction float64
+}
+
+// IsEmpty returns true if the payload has no destination to distribute to
+func (p DistributionPayload) IsEmpty() bool {
+	return len(p.GroupNames) == 0 && len(p.Testers) == 0 && len(p.StoreNames) == 0
 }
 
 // ReleaseUploadPayload wrap optional informations about the release
@@ -36,90 +47,196 @@ type MetadataResponse struct {
 
 // Do start the upload request witht the provided parameters
 func (s *UploadService) Do(ctx context.Context, r UploadTask) (int64, error) {
-	if err := r.validateRequest(); err != nil {
-		return -1, err
+	sum := newUploadSummary()
+	err := s.upload(ctx, r, sum)
+	return sum.ReleaseID, err
+}
+
+// Run uploads the binary, then distributes the release to the destinations of the task. The
+// summary is returned even in case of error, completed up to the failing stage.
+func (s *UploadService) Run(ctx context.Context, r UploadTask) (*UploadSummary, error) {
+	sum := newUploadSummary()
+
+	err := s.upload(ctx, r, sum)
+	if err == nil && !r.Distribute.IsEmpty() {
+		ctx = withLogger(ctx, s.client.logger(ctx).With().
+			Str("File", r.name()).
+			Str("UploadID", sum.UploadID).
+			Int64("ReleaseID", sum.ReleaseID).
+			Logger())
+
+		end := s.stage(ctx, sum, StageDistribute)
+		sum.Distributions, err = s.client.Distribute.ReleaseResults(ctx, sum.ReleaseID, r.Distribute)
+		end()
 	}
 
-	// Request Upload "slot"
-	ur, err := s.RequestUploadResource(ctx, r)
 	if err != nil {
-		return -1, err
+		sum.Error = err.Error()
 	}
 
-	// convert to absolute path
-	p, err := filepath.Abs(r.FilePath)
-	if err != nil {
-		return -1, NewAppCenterError(InputFileError, err)
+	return sum, err
+}
+
+// stage logs the start and the end of the stage, and records its timing in the summary once the
+// returned function is called. The stages failing do not call it, their error being returned.
+func (s *UploadService) stage(ctx context.Context, sum *UploadSummary, stage string) func() {
+	l := s.client.logger(ctx)
+	l.Debug().Str("Stage", stage).Msg("Stage started")
+
+	end := sum.begin(stage)
+	return func() {
+		end()
+		l.Debug().
+			Str("Stage", stage).
+			Int64("DurationMS", sum.Timings[len(sum.Timings)-1].DurationMS).
+			Msg("Stage completed")
+	}
+}
+
+// upload runs the stages of the upload. The logs of the stages carry the name of the binary, and
+// its upload ID once requested, to correlate the logs of concurrent uploads.
+func (s *UploadService) upload(ctx context.Context, r UploadTask, sum *UploadSummary) error {
+	if err := r.validateSource(); err != nil {
+		return NewAppCenterError(InputFileError, err)
+	}
+
+	src := r.Source
+	if src == nil {
+		opened, err := OpenSource(ctx, r.FilePath)
+		if err != nil {
+			return NewAppCenterError(InputFileError, err)
+		}
+		defer opened.Close()
+		src = opened
+	}
+
+	ctx = withLogger(ctx, s.client.logger(ctx).With().Str("File", src.Name()).Logger())
+
+	end := s.stage(ctx, sum, StageInspect)
+	info := r.inspect(src, s.client.logger(ctx))
+	end()
+
+	end = s.stage(ctx, sum, StageValidate)
+	if err := r.validateRequest(src); err != nil {
+		return NewAppCenterError(InputFileError, err)
+	}
+
+	if info != nil && info.Profile != nil {
+		renderProfile(info.Profile, s.client.UI)
+	}
+
+	if info != nil && !r.Force {
+		if err := s.ValidateBinary(ctx, info, r.Distribute); err != nil {
+			return err
+		}
 	}
-	contentType := ResolveContentType(filepath.Ext(p))
+	end()
 
-	// get target file infos
-	fi, err := os.Stat(p)
+	// Request Upload "slot"
+	end = s.stage(ctx, sum, StageRequest)
+	ur, err := s.RequestUploadResource(ctx, r)
 	if err != nil {
-		return -1, NewAppCenterError(InputFileError, err)
+		return err
 	}
+	end()
+
+	sum.UploadID = ur.ID
+	ctx = withLogger(ctx, s.client.logger(ctx).With().Str("UploadID", ur.ID).Logger())
+
+	contentType := r.contentType(src, s.client.logger(ctx))
 
 	// Metadatas
+	end = s.stage(ctx, sum, StageMetadata)
 	meta, err := s.SetMetaData(
 		ctx,
 		ur.UploadDomain,
 		ur.PackageAssetID,
-		fi.Name(),
-		fi.Size(),
+		src.Name(),
+		src.Size(),
 		ur.URLEncodedToken,
 		contentType,
 		r.Option.BuildNumber,
 		r.Option.BuildVersion,
 	)
 	if err != nil {
-		return -1, err
+		return err
 	}
-
-	// Opening the file
-	reader, err := os.Open(r.FilePath)
-	if err != nil {
-		return -1, err
-	}
-	defer reader.Close()
+	end()
 
 	// Uploading chunks
+	end = s.stage(ctx, sum, StageUpload)
+	fingerprint := newFingerprint()
 	err = s.UploadChunks(
 		ctx,
-		reader,
+		io.TeeReader(io.NewSectionReader(src, 0, src.Size()), fingerprint),
 		ur.UploadDomain,
 		ur.ID,
 		ur.PackageAssetID,
 		ur.URLEncodedToken,
 		*meta.ChunkSize,
 		len(meta.ChunkList),
-		fi.Size(),
+		src.Size(),
 		contentType,
 	)
 
 	if err != nil {
-		return -1, err
+		return err
 	}
+	end()
 
 	// finishing upload
+	end = s.stage(ctx, sum, StageFinish)
 	_, err = s.FinishingUpload(ctx, ur.UploadDomain, ur.PackageAssetID, ur.URLEncodedToken, ur.ID)
 	if err != nil {
-		return -1, err
+		return NewAppCenterError(CommitError, err)
 	}
+	end()
 
 	// Committing release
+	end = s.stage(ctx, sum, StageCommit)
 	_, err = s.UploadCommitRelease(ctx, *meta.ID, ur.ID)
 	if err != nil {
-		return -1, err
+		return NewAppCenterError(CommitError, err)
 	}
+	end()
 
+	end = s.stage(ctx, sum, StageProcessing)
 	rdid, err := s.PollForRelease(ctx, ur.ID)
 	if err != nil {
-		return -1, err
+		return err
+	}
+	end()
+
+	sum.ReleaseID = rdid
+
+	if r.ReleaseNotes != "" {
+		end = s.stage(ctx, sum, StageNotes)
+		if err := s.client.Releases.UpdateNotes(ctx, rdid, r.ReleaseNotes); err != nil {
+			return err
+		}
+		end()
 	}
 
-	if err := s.UploadResult(ctx, rdid); err != nil {
-		return -1, err
+	end = s.stage(ctx, sum, StageDetails)
+	sum.Release, err = s.UploadResult(ctx, rdid, newBinaryReport(info, fingerprint))
+	if err != nil {
+		return err
+	}
+	end()
+
+	if r.Symbols != "" {
+		if info == nil || info.Platform != inspect.PlatformIOS {
+			s.client.logger(ctx).Warn().Msg("dSYMs are only looked up for IPA binaries")
+		} else {
+			end = s.stage(ctx, sum, StageSymbols)
+			report, err := s.client.Symbols.UploadDSYMs(ctx, src, r.Symbols)
+			sum.Symbols = report
+			if err != nil {
+				return err
+			}
+			end()
+		}
 	}
 
-	return rdid, nil
+	return nil
 }
@@ -0,0 +1,107 @@
+package appcenter
+
+import (
+	"strconv"
+	"time"
+)
+
+// Stages of the upload pipeline, as reported in the timings of the upload summary
+const (
+	StageInspect    = "inspect"
+	StageValidate   = "validate"
+	StageRequest    = "request"
+	StageMetadata   = "metadata"
+	StageUpload     = "upload"
+	StageFinish     = "finish"
+	StageCommit     = "commit"
+	StageProcessing = "processing"
+	StageNotes      = "release_notes"
+	StageDetails    = "details"
+	StageSymbols    = "symbols"
+	StageDistribute = "distribute"
+)
+
+// Destination types of the distribution results
+const (
+	DestinationGroup  = "group"
+	DestinationTester = "tester"
+	DestinationStore  = "store"
+)
+
+// StageTiming is the time spent in a stage of the upload pipeline
+type StageTiming struct {
+	Stage      string `json:"stage"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// DistributionResult is the result of the distribution of a release to a destination
+type DistributionResult struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// UploadSummary is the machine readable result of an upload: the release created, the results of
+// its distribution and the time spent in each stage of the pipeline
+type UploadSummary struct {
+	UploadID      string               `json:"upload_id,omitempty"`
+	ReleaseID     int64                `json:"release_id"`
+	Release       *ReleaseDetails      `json:"release,omitempty"`
+	Distributions []DistributionResult `json:"distributions,omitempty"`
+	Symbols       *DSYMReport          `json:"symbols,omitempty"`
+	Timings       []StageTiming        `json:"timings"`
+	Error         string               `json:"error,omitempty"`
+}
+
+// SummaryOutput is a named value of the upload summary, exported to the scripts and CI systems
+type SummaryOutput struct {
+	Name  string
+	Value string
+}
+
+// failed records the error of the distribution, if any
+func (r DistributionResult) failed(err error) DistributionResult {
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+func newUploadSummary() *UploadSummary {
+	return &UploadSummary{ReleaseID: -1, Timings: []StageTiming{}}
+}
+
+// begin starts the timing of a stage, recorded once the returned function is called
+func (s *UploadSummary) begin(stage string) func() {
+	start := time.Now()
+	return func() {
+		s.Timings = append(s.Timings, StageTiming{
+			Stage:      stage,
+			DurationMS: time.Since(start).Milliseconds(),
+		})
+	}
+}
+
+// Outputs returns the identifiers, version and URLs of the release. The values of the release
+// are empty when the upload failed before the release details were retrieved.
+func (s *UploadSummary) Outputs() []SummaryOutput {
+	release := ReleaseDetails{}
+	if s.Release != nil {
+		release = *s.Release
+	}
+
+	id := ""
+	if s.ReleaseID > 0 {
+		id = strconv.FormatInt(s.ReleaseID, 10)
+	}
+
+	return []SummaryOutput{
+		{"upload_id", s.UploadID},
+		{"release_id", id},
+		{"version", release.ShortVersion},
+		{"build", release.Version},
+		{"install_url", release.InstallURL},
+		{"download_url", release.DownloadURL},
+	}
+}
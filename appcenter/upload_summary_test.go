@@ -0,0 +1,91 @@
+package appcenter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadSummaryOutputs(t *testing.T) {
+	sum := newUploadSummary()
+	sum.UploadID = "upload-id"
+
+	assert.Equal(t, []SummaryOutput{
+		{"upload_id", "upload-id"},
+		{"release_id", ""},
+		{"version", ""},
+		{"build", ""},
+		{"install_url", ""},
+		{"download_url", ""},
+	}, sum.Outputs())
+
+	sum.ReleaseID = 12
+	sum.Release = &ReleaseDetails{
+		ID:           12,
+		ShortVersion: "1.2.3",
+		Version:      "45",
+		InstallURL:   "https://install.appcenter.ms/orgs/owner/apps/app/releases/12",
+		DownloadURL:  "https://download.example.com/app.ipa",
+	}
+
+	assert.Equal(t, []SummaryOutput{
+		{"upload_id", "upload-id"},
+		{"release_id", "12"},
+		{"version", "1.2.3"},
+		{"build", "45"},
+		{"install_url", "https://install.appcenter.ms/orgs/owner/apps/app/releases/12"},
+		{"download_url", "https://download.example.com/app.ipa"},
+	}, sum.Outputs())
+}
+
+func TestUploadSummaryTimings(t *testing.T) {
+	sum := newUploadSummary()
+
+	end := sum.begin(StageInspect)
+	end()
+	end = sum.begin(StageUpload)
+	end()
+
+	assert.Len(t, sum.Timings, 2)
+	assert.Equal(t, StageInspect, sum.Timings[0].Stage)
+	assert.Equal(t, StageUpload, sum.Timings[1].Stage)
+
+	data, err := json.Marshal(sum)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"timings":[{"stage":"inspect","duration_ms":`)
+}
+
+func TestDistributionResults(t *testing.T) {
+	c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/apps/owner/app/distribution_groups/Beta":
+			w.Write([]byte(`{"id": "group-id", "name": "Beta"}`))
+
+		case r.Method == http.MethodPost && r.URL.Path == "/apps/owner/app/releases/12/groups":
+			w.Write([]byte(`{"id": "group-id"}`))
+
+		case r.Method == http.MethodPost && r.URL.Path == "/apps/owner/app/releases/12/testers":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "NotFound", "message": "Not found"}`))
+
+		default:
+			t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+		}
+	})
+	defer done()
+
+	res, err := c.Distribute.ReleaseResults(context.Background(), 12, DistributionPayload{
+		GroupNames: []string{"Beta"},
+		Testers:    []string{"a@example.com", "b@example.com"},
+	})
+
+	assert.Error(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, DistributionResult{Type: DestinationGroup, Name: "Beta", ID: "group-id"}, res[0])
+	assert.Equal(t, DestinationTester, res[1].Type)
+	assert.Equal(t, "a@example.com", res[1].Name)
+	assert.NotEmpty(t, res[1].Error)
+}
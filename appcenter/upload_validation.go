@@ -0,0 +1,136 @@
+package appcenter
+
+import (
+	"context"
+	"fmt"
+	"goappcenter/inspect"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// release types of the applications for which debug builds are refused
+var productionReleaseTypes = []string{"Production", "Store"}
+
+// binaryPlatforms are the platforms of the binaries accepted by the applications of an OS, when
+// they differ from the OS itself
+var binaryPlatforms = map[string][]string{
+	"tvos": {inspect.PlatformIOS},
+}
+
+// profileExpiryWarning is the delay before the expiration of a provisioning profile from which
+// a warning is printed
+var profileExpiryWarning = 14 * 24 * time.Hour
+
+// ValidateBinary checks that the inspected binary can be uploaded to the configured application:
+// the platform and the bundle identifier must match the application, and production applications
+// only accept release builds. Signed binaries are required for iOS and Android, and the
+// provisioning profile of the iOS binaries must be valid for the distribution.
+func (s *UploadService) ValidateBinary(ctx context.Context, info *inspect.Info, d DistributionPayload) error {
+	sp, err := s.client.UI.Start("Validating binary")
+	if err != nil {
+		return err
+	}
+
+	problems, err := s.validateBinary(ctx, info)
+	if err != nil {
+		sp.Fail()
+		return err
+	}
+
+	if info.Profile != nil {
+		problems = append(problems, validateProfile(info.Profile, d, time.Now(), s.client.logger(ctx))...)
+	}
+
+	if len(problems) > 0 {
+		sp.Fail()
+		return NewAppCenterError(ValidationError,
+			fmt.Errorf("%v (use --force to upload anyway)", strings.Join(problems, ", ")))
+	}
+
+	sp.Success("Binary validated")
+
+	return nil
+}
+
+func (s *UploadService) validateBinary(ctx context.Context, info *inspect.Info) ([]string, error) {
+	app, err := s.client.Apps.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []string{}
+	if !acceptsPlatform(app.OS, info.Platform) {
+		problems = append(problems,
+			fmt.Sprintf("%v binary cannot be uploaded to the %v application '%v'", info.Platform, app.OS, app.Name))
+	}
+
+	// the apps API does not expose the bundle identifier, the one of the latest release is used
+	latest, err := s.client.Releases.Latest(ctx)
+	if se, ok := err.(*StatusError); ok && se.StatusCode == http.StatusNotFound {
+		s.client.logger(ctx).Debug().Err(err).Msg("No previous release to compare the bundle identifier with")
+	} else if err != nil {
+		return nil, err
+	} else if info.Identifier != "" && latest.BundleIdentifier != "" && latest.BundleIdentifier != info.Identifier {
+		problems = append(problems,
+			fmt.Sprintf("bundle identifier '%v' does not match the application one '%v'", info.Identifier, latest.BundleIdentifier))
+	}
+
+	if info.Debuggable {
+		for _, t := range productionReleaseTypes {
+			if strings.EqualFold(app.ReleaseType, t) {
+				problems = append(problems,
+					fmt.Sprintf("debug build cannot be uploaded to the %v application '%v'", app.ReleaseType, app.Name))
+				break
+			}
+		}
+	}
+
+	if !info.Signed && (info.Platform == inspect.PlatformIOS || info.Platform == inspect.PlatformAndroid) {
+		problems = append(problems, "binary is not signed")
+	}
+
+	return problems, nil
+}
+
+// acceptsPlatform tells whether the applications of the OS accept the binaries of the platform
+func acceptsPlatform(appOS, platform string) bool {
+	if strings.EqualFold(appOS, platform) {
+		return true
+	}
+
+	for _, p := range binaryPlatforms[strings.ToLower(appOS)] {
+		if p == platform {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateProfile checks that the provisioning profile is not expired and allows the testers to
+// install the release
+func validateProfile(p *inspect.ProvisioningProfile, d DistributionPayload, now time.Time, l *zerolog.Logger) []string {
+	problems := []string{}
+
+	if p.ExpirationDate.IsZero() {
+		l.Warn().Str("Profile", p.Name).Msg("The expiration date of the provisioning profile is unknown")
+	} else if p.ExpiresWithin(0, now) {
+		problems = append(problems,
+			fmt.Sprintf("provisioning profile '%v' expired on %v", p.Name, p.ExpirationDate.Format("2006-01-02")))
+	} else if p.ExpiresWithin(profileExpiryWarning, now) {
+		l.Warn().
+			Str("Profile", p.Name).
+			Time("Expiration", p.ExpirationDate).
+			Msg("The provisioning profile expires soon")
+	}
+
+	if p.Type == inspect.ProfileAppStore && (len(d.GroupNames) > 0 || len(d.Testers) > 0) {
+		problems = append(problems,
+			fmt.Sprintf("App Store provisioning profile '%v' cannot be installed by the testers", p.Name))
+	}
+
+	return problems
+}
@@ -0,0 +1,130 @@
+package appcenter
+
+import (
+	"context"
+	"encoding/json"
+	"goappcenter/inspect"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBinary(t *testing.T) {
+	serve := func(app App, latest *ReleaseDetails) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/apps/owner/app":
+				assert.NoError(t, json.NewEncoder(w).Encode(app))
+			case "/apps/owner/app/releases/latest":
+				if latest == nil {
+					w.WriteHeader(http.StatusNotFound)
+					assert.NoError(t, json.NewEncoder(w).Encode(StatusError{Code: "NotFound"}))
+					return
+				}
+				assert.NoError(t, json.NewEncoder(w).Encode(latest))
+			default:
+				t.Errorf("Unexpected request %v", r.URL.Path)
+			}
+		}
+	}
+
+	release := &ReleaseDetails{BundleIdentifier: "com.test.app"}
+	binary := inspect.Info{Platform: inspect.PlatformAndroid, Identifier: "com.test.app", Signed: true}
+
+	testCases := []struct {
+		name     string
+		app      App
+		latest   *ReleaseDetails
+		info     func(i *inspect.Info)
+		problems []string
+	}{
+		{"Matching binary", App{OS: "Android"}, release, nil, []string{}},
+		{"No previous release", App{OS: "Android"}, nil, nil, []string{}},
+		{"Wrong platform", App{Name: "app", OS: "iOS"}, release, nil,
+			[]string{"Android binary cannot be uploaded to the iOS application 'app'"}},
+		{"iOS binary to a tvOS app", App{OS: "tvOS"}, release,
+			func(i *inspect.Info) { i.Platform = inspect.PlatformIOS }, []string{}},
+		{"Android binary to a tvOS app", App{Name: "app", OS: "tvOS"}, release, nil,
+			[]string{"Android binary cannot be uploaded to the tvOS application 'app'"}},
+		{"Wrong bundle identifier", App{OS: "Android"}, release,
+			func(i *inspect.Info) { i.Identifier = "com.other.app" },
+			[]string{"bundle identifier 'com.other.app' does not match the application one 'com.test.app'"}},
+		{"Debug build to a beta app", App{OS: "Android", ReleaseType: "Beta"}, release,
+			func(i *inspect.Info) { i.Debuggable = true }, []string{}},
+		{"Debug build to a production app", App{Name: "app", OS: "Android", ReleaseType: "Production"}, release,
+			func(i *inspect.Info) { i.Debuggable = true },
+			[]string{"debug build cannot be uploaded to the Production application 'app'"}},
+		{"Unsigned binary", App{OS: "Android"}, release,
+			func(i *inspect.Info) { i.Signed = false },
+			[]string{"binary is not signed"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, done := newTestClient(t, serve(tc.app, tc.latest))
+			defer done()
+
+			info := binary
+			if tc.info != nil {
+				tc.info(&info)
+			}
+
+			problems, err := c.Upload.validateBinary(context.Background(), &info)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.problems, problems)
+		})
+	}
+
+	t.Run("Failing to get the latest release", func(t *testing.T) {
+		c, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/apps/owner/app" {
+				assert.NoError(t, json.NewEncoder(w).Encode(App{OS: "Android"}))
+				return
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+			assert.NoError(t, json.NewEncoder(w).Encode(StatusError{Code: "Unauthorized"}))
+		})
+		defer done()
+
+		info := binary
+		_, err := c.Upload.validateBinary(context.Background(), &info)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateProfile(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	profile := func(typ string, expiration time.Time) *inspect.ProvisioningProfile {
+		return &inspect.ProvisioningProfile{Name: "profile", Type: typ, ExpirationDate: expiration}
+	}
+
+	testCases := []struct {
+		name     string
+		profile  *inspect.ProvisioningProfile
+		d        DistributionPayload
+		problems []string
+	}{
+		{"Valid profile", profile(inspect.ProfileAdHoc, now.AddDate(1, 0, 0)),
+			DistributionPayload{GroupNames: []string{"Beta"}}, []string{}},
+		{"Profile expiring soon", profile(inspect.ProfileAdHoc, now.AddDate(0, 0, 1)),
+			DistributionPayload{}, []string{}},
+		{"Expired profile", profile(inspect.ProfileAdHoc, now.AddDate(0, 0, -1)),
+			DistributionPayload{}, []string{"provisioning profile 'profile' expired on 2020-12-31"}},
+		{"Unknown expiration date", profile(inspect.ProfileAdHoc, time.Time{}),
+			DistributionPayload{}, []string{}},
+		{"App Store profile to a store", profile(inspect.ProfileAppStore, now.AddDate(1, 0, 0)),
+			DistributionPayload{StoreNames: []string{"App Store"}}, []string{}},
+		{"App Store profile to a group", profile(inspect.ProfileAppStore, now.AddDate(1, 0, 0)),
+			DistributionPayload{GroupNames: []string{"Beta"}},
+			[]string{"App Store provisioning profile 'profile' cannot be installed by the testers"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.problems, validateProfile(tc.profile, tc.d, now, &log.Logger))
+		})
+	}
+}
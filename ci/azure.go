@@ -0,0 +1,61 @@
+package ci
+
+import (
+	"fmt"
+	"goappcenter/appcenter"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// azure sets the output variables and uploads the summary with the ##vso logging commands
+type azure struct {
+	env Environment
+}
+
+func newAzure(e Environment) System {
+	return azure{env: e}
+}
+
+func (a azure) Name() string {
+	return "Azure Pipelines"
+}
+
+func (a azure) SetOutputs(outputs []appcenter.SummaryOutput) error {
+	for _, o := range outputs {
+		if _, err := fmt.Fprintf(a.env.Messages, "##vso[task.setvariable variable=%v;isOutput=true]%v\n",
+			variableName(o.Name), azureEscape(o.Value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a azure) Summary(markdown string) error {
+	dir := a.env.Getenv("AGENT_TEMPDIRECTORY")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+
+	f, err := ioutil.TempFile(dir, "appcenter-summary-*.md")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.WriteString(markdown)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(a.env.Messages, "##vso[task.uploadsummary]%v\n", f.Name())
+	return err
+}
+
+// azureEscape escapes the values of the logging commands
+func azureEscape(v string) string {
+	return strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", "]", "%5D", ";", "%3B").Replace(v)
+}
@@ -0,0 +1,34 @@
+package ci
+
+import (
+	"goappcenter/appcenter"
+	"io/ioutil"
+)
+
+// bitrise exports the variables to the next steps with envman, and writes the summary to the
+// deploy directory to be attached to the build
+type bitrise struct {
+	env Environment
+}
+
+func newBitrise(e Environment) System {
+	return bitrise{env: e}
+}
+
+func (b bitrise) Name() string {
+	return "Bitrise"
+}
+
+func (b bitrise) SetOutputs(outputs []appcenter.SummaryOutput) error {
+	for _, o := range outputs {
+		if err := b.env.Run("envman", "add", "--key", variableName(o.Name), "--value", o.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b bitrise) Summary(markdown string) error {
+	return ioutil.WriteFile(b.env.path("BITRISE_DEPLOY_DIR", summaryFile), []byte(markdown), 0644)
+}
@@ -0,0 +1,150 @@
+// Package ci exports the result of the uploads to the CI system running the job: step outputs,
+// variables and job summaries
+package ci
+
+import (
+	"fmt"
+	"goappcenter/appcenter"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// summaryFile is the name of the Markdown summary written by the systems without native job
+// summaries, to be published as an artifact
+const summaryFile = "appcenter-summary.md"
+
+// System is a CI system the upload result is exported to
+type System interface {
+	// Name of the CI system
+	Name() string
+
+	// SetOutputs exports the values as step outputs or variables of the job
+	SetOutputs(outputs []appcenter.SummaryOutput) error
+
+	// Summary publishes the Markdown summary of the job
+	Summary(markdown string) error
+}
+
+// Environment is the environment of the CI job
+type Environment struct {
+	// Getenv returns the value of an environment variable
+	Getenv func(key string) string
+
+	// Messages receives the service messages of the systems parsing the job logs
+	Messages io.Writer
+
+	// Run runs a command, for the systems relying on a CLI tool
+	Run func(name string, args ...string) error
+}
+
+// DefaultEnvironment is the environment of the current process. The service messages are written
+// to the standard output, or to the standard error when the standard output holds the result of
+// the command, as the json output.
+func DefaultEnvironment(resultOnStdout bool) Environment {
+	var messages io.Writer = os.Stdout
+	if resultOnStdout {
+		messages = os.Stderr
+	}
+
+	return Environment{
+		Getenv:   os.Getenv,
+		Messages: messages,
+		Run: func(name string, args ...string) error {
+			cmd := exec.Command(name, args...)
+			cmd.Stderr = os.Stderr
+			return cmd.Run()
+		},
+	}
+}
+
+// path returns the path of the file in the directory of the environment variable, or in the
+// working directory if it is not set
+func (e Environment) path(dirVariable string, name string) string {
+	if dir := e.Getenv(dirVariable); dir != "" {
+		return filepath.Join(dir, name)
+	}
+
+	return name
+}
+
+type system struct {
+	name   string
+	detect func(e Environment) bool
+	new    func(e Environment) System
+}
+
+// systems are the supported CI systems, in their detection order
+var systems = []system{
+	{"github", func(e Environment) bool { return e.Getenv("GITHUB_ACTIONS") == "true" }, newGitHub},
+	{"gitlab", func(e Environment) bool { return e.Getenv("GITLAB_CI") != "" }, newGitLab},
+	{"azure", func(e Environment) bool { return strings.EqualFold(e.Getenv("TF_BUILD"), "true") }, newAzure},
+	{"bitrise", func(e Environment) bool { return e.Getenv("BITRISE_IO") != "" }, newBitrise},
+	{"teamcity", func(e Environment) bool { return e.Getenv("TEAMCITY_VERSION") != "" }, newTeamCity},
+	{"jenkins", func(e Environment) bool { return e.Getenv("JENKINS_URL") != "" }, newJenkins},
+}
+
+// Names returns the names of the supported CI systems
+func Names() []string {
+	names := []string{}
+	for _, s := range systems {
+		names = append(names, s.name)
+	}
+
+	return names
+}
+
+// Detect returns the CI system running the job, or nil if none is detected
+func Detect(e Environment) System {
+	for _, s := range systems {
+		if s.detect(e) {
+			return s.new(e)
+		}
+	}
+
+	return nil
+}
+
+// Named returns the CI system with the provided name
+func Named(name string, e Environment) (System, error) {
+	for _, s := range systems {
+		if s.name == strings.ToLower(name) {
+			return s.new(e), nil
+		}
+	}
+
+	return nil, fmt.Errorf("Unsupported CI system '%v', expecting one of %v", name, strings.Join(Names(), ", "))
+}
+
+// variableName returns the name of the output as an environment variable (ex: APPCENTER_RELEASE_ID)
+func variableName(name string) string {
+	return "APPCENTER_" + strings.ToUpper(name)
+}
+
+// appendFile appends the content to the file, created if needed
+func appendFile(path string, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.WriteString(content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+// writeVariables writes the outputs as KEY=value lines, the format of dotenv and properties files
+func writeVariables(path string, outputs []appcenter.SummaryOutput, escape func(string) string) error {
+	var b strings.Builder
+	for _, o := range outputs {
+		fmt.Fprintf(&b, "%v=%v\n", variableName(o.Name), escape(o.Value))
+	}
+
+	return ioutil.WriteFile(path, []byte(b.String()), 0644)
+}
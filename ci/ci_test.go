@@ -0,0 +1,225 @@
+package ci
+
+import (
+	"bytes"
+	"goappcenter/appcenter"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testOutputs = []appcenter.SummaryOutput{
+	{Name: "release_id", Value: "12"},
+	{Name: "version", Value: "1.2.3"},
+	{Name: "install_url", Value: "https://install.appcenter.ms/orgs/owner/apps/app/releases/12?a=b;c"},
+}
+
+// testEnvironment returns an environment with the provided variables, recording the service
+// messages and the commands run
+func testEnvironment(vars map[string]string) (Environment, *bytes.Buffer, *[][]string) {
+	stdout := &bytes.Buffer{}
+	commands := &[][]string{}
+
+	return Environment{
+		Getenv:   func(key string) string { return vars[key] },
+		Messages: stdout,
+		Run: func(name string, args ...string) error {
+			*commands = append(*commands, append([]string{name}, args...))
+			return nil
+		},
+	}, stdout, commands
+}
+
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "ci")
+	assert.NoError(t, err)
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		vars map[string]string
+		name string
+	}{
+		{map[string]string{}, ""},
+		{map[string]string{"GITHUB_ACTIONS": "true"}, "GitHub Actions"},
+		{map[string]string{"GITLAB_CI": "true"}, "GitLab CI"},
+		{map[string]string{"TF_BUILD": "True"}, "Azure Pipelines"},
+		{map[string]string{"BITRISE_IO": "true"}, "Bitrise"},
+		{map[string]string{"TEAMCITY_VERSION": "2020.2"}, "TeamCity"},
+		{map[string]string{"JENKINS_URL": "https://jenkins.example.com"}, "Jenkins"},
+	}
+
+	for _, tc := range testCases {
+		env, _, _ := testEnvironment(tc.vars)
+
+		s := Detect(env)
+		if tc.name == "" {
+			assert.Nil(t, s)
+		} else if assert.NotNil(t, s) {
+			assert.Equal(t, tc.name, s.Name())
+		}
+	}
+
+	env, _, _ := testEnvironment(nil)
+	s, err := Named("TeamCity", env)
+	assert.NoError(t, err)
+	assert.Equal(t, "TeamCity", s.Name())
+
+	_, err = Named("travis", env)
+	assert.Error(t, err)
+}
+
+func TestDefaultEnvironment(t *testing.T) {
+	assert.Equal(t, os.Stdout, DefaultEnvironment(false).Messages)
+	assert.Equal(t, os.Stderr, DefaultEnvironment(true).Messages, "the service messages must not mix with the result")
+}
+
+func TestGitHub(t *testing.T) {
+	dir, done := tempDir(t)
+	defer done()
+
+	output := filepath.Join(dir, "output")
+	summary := filepath.Join(dir, "summary")
+	assert.NoError(t, ioutil.WriteFile(output, []byte("previous=1\n"), 0644))
+
+	env, stdout, _ := testEnvironment(map[string]string{"GITHUB_OUTPUT": output, "GITHUB_STEP_SUMMARY": summary})
+	s := newGitHub(env)
+
+	outputs := append(testOutputs, appcenter.SummaryOutput{Name: "notes", Value: "line 1\nline 2"})
+	assert.NoError(t, s.SetOutputs(outputs))
+	assert.NoError(t, s.Summary("### Summary\n"))
+
+	assert.Equal(t, "previous=1\n"+
+		"release_id=12\n"+
+		"version=1.2.3\n"+
+		"install_url=https://install.appcenter.ms/orgs/owner/apps/app/releases/12?a=b;c\n"+
+		"notes<<APPCENTER_EOF\nline 1\nline 2\nAPPCENTER_EOF\n", readFile(t, output))
+	assert.Equal(t, "### Summary\n", readFile(t, summary))
+	assert.Empty(t, stdout.String())
+
+	env, _, _ = testEnvironment(nil)
+	assert.Error(t, newGitHub(env).SetOutputs(testOutputs))
+}
+
+func TestGitLab(t *testing.T) {
+	dir, done := tempDir(t)
+	defer done()
+
+	env, stdout, _ := testEnvironment(map[string]string{"CI_PROJECT_DIR": dir})
+	s := newGitLab(env)
+
+	assert.NoError(t, s.SetOutputs(testOutputs))
+	assert.NoError(t, s.Summary("### Summary\n"))
+
+	assert.Equal(t, "APPCENTER_RELEASE_ID=12\n"+
+		"APPCENTER_VERSION=1.2.3\n"+
+		"APPCENTER_INSTALL_URL=https://install.appcenter.ms/orgs/owner/apps/app/releases/12?a=b;c\n",
+		readFile(t, filepath.Join(dir, dotenvFile)))
+	assert.Equal(t, "### Summary\n", readFile(t, filepath.Join(dir, summaryFile)))
+	assert.Empty(t, stdout.String())
+}
+
+func TestAzure(t *testing.T) {
+	dir, done := tempDir(t)
+	defer done()
+
+	env, stdout, _ := testEnvironment(map[string]string{"AGENT_TEMPDIRECTORY": dir})
+	s := newAzure(env)
+
+	assert.NoError(t, s.SetOutputs(testOutputs))
+	assert.Equal(t, "##vso[task.setvariable variable=APPCENTER_RELEASE_ID;isOutput=true]12\n"+
+		"##vso[task.setvariable variable=APPCENTER_VERSION;isOutput=true]1.2.3\n"+
+		"##vso[task.setvariable variable=APPCENTER_INSTALL_URL;isOutput=true]"+
+		"https://install.appcenter.ms/orgs/owner/apps/app/releases/12?a=b%3Bc\n", stdout.String())
+
+	stdout.Reset()
+	assert.NoError(t, s.Summary("### Summary\n"))
+
+	line := strings.TrimSpace(stdout.String())
+	assert.True(t, strings.HasPrefix(line, "##vso[task.uploadsummary]"+dir))
+	assert.Equal(t, "### Summary\n", readFile(t, strings.TrimPrefix(line, "##vso[task.uploadsummary]")))
+}
+
+func TestBitrise(t *testing.T) {
+	dir, done := tempDir(t)
+	defer done()
+
+	env, stdout, commands := testEnvironment(map[string]string{"BITRISE_DEPLOY_DIR": dir})
+	s := newBitrise(env)
+
+	assert.NoError(t, s.SetOutputs(testOutputs[:2]))
+	assert.NoError(t, s.Summary("### Summary\n"))
+
+	assert.Equal(t, [][]string{
+		{"envman", "add", "--key", "APPCENTER_RELEASE_ID", "--value", "12"},
+		{"envman", "add", "--key", "APPCENTER_VERSION", "--value", "1.2.3"},
+	}, *commands)
+	assert.Equal(t, "### Summary\n", readFile(t, filepath.Join(dir, summaryFile)))
+	assert.Empty(t, stdout.String())
+}
+
+func TestJenkins(t *testing.T) {
+	dir, done := tempDir(t)
+	defer done()
+
+	env, stdout, _ := testEnvironment(map[string]string{"WORKSPACE": dir})
+	s := newJenkins(env)
+
+	assert.NoError(t, s.SetOutputs(testOutputs))
+	assert.NoError(t, s.Summary("### Summary\n"))
+
+	assert.Equal(t, "APPCENTER_RELEASE_ID=12\n"+
+		"APPCENTER_VERSION=1.2.3\n"+
+		`APPCENTER_INSTALL_URL=https\://install.appcenter.ms/orgs/owner/apps/app/releases/12?a\=b;c`+"\n",
+		readFile(t, filepath.Join(dir, propertiesFile)))
+	assert.Equal(t, "### Summary\n", readFile(t, filepath.Join(dir, summaryFile)))
+	assert.Empty(t, stdout.String())
+}
+
+func TestTeamCity(t *testing.T) {
+	dir, done := tempDir(t)
+	defer done()
+
+	env, stdout, _ := testEnvironment(map[string]string{"TEAMCITY_BUILD_CHECKOUTDIR": dir})
+	s := newTeamCity(env)
+
+	assert.NoError(t, s.SetOutputs([]appcenter.SummaryOutput{{Name: "version", Value: "1.2.3 [it's]"}}))
+	assert.NoError(t, s.Summary("### Summary\n"))
+
+	assert.Equal(t, "##teamcity[setParameter name='env.APPCENTER_VERSION' value='1.2.3 |[it|'s|]']\n"+
+		"##teamcity[publishArtifacts '"+filepath.ToSlash(filepath.Join(dir, summaryFile))+"']\n", stdout.String())
+	assert.Equal(t, "### Summary\n", readFile(t, filepath.Join(dir, summaryFile)))
+}
+
+func TestMarkdown(t *testing.T) {
+	summaries := []*appcenter.UploadSummary{
+		{
+			ReleaseID: 12,
+			Release: &appcenter.ReleaseDetails{
+				AppDisplayName: "My App",
+				ShortVersion:   "1.2.3",
+				Version:        "45",
+				InstallURL:     "https://install.appcenter.ms/orgs/owner/apps/app/releases/12",
+			},
+			Distributions: []appcenter.DistributionResult{{Type: appcenter.DestinationGroup, Name: "Beta"}},
+		},
+		{ReleaseID: -1, Error: "File `app.apk` does not exsts | really"},
+	}
+
+	assert.Equal(t, "### AppCenter upload\n\n"+
+		"| App | Release | Version | Destinations | Install | Status |\n"+
+		"| --- | --- | --- | --- | --- | --- |\n"+
+		"| My App | 12 | 1.2.3 (45) | Beta | [Install](https://install.appcenter.ms/orgs/owner/apps/app/releases/12) | ✅ Uploaded |\n"+
+		"|  |  |  |  |  | ❌ File `app.apk` does not exsts \\| really |\n", Markdown(summaries))
+}
@@ -0,0 +1,48 @@
+package ci
+
+import (
+	"fmt"
+	"goappcenter/appcenter"
+	"strings"
+)
+
+// gitHub writes the step outputs to $GITHUB_OUTPUT and the job summary to $GITHUB_STEP_SUMMARY
+type gitHub struct {
+	env Environment
+}
+
+func newGitHub(e Environment) System {
+	return gitHub{env: e}
+}
+
+func (g gitHub) Name() string {
+	return "GitHub Actions"
+}
+
+func (g gitHub) SetOutputs(outputs []appcenter.SummaryOutput) error {
+	path := g.env.Getenv("GITHUB_OUTPUT")
+	if path == "" {
+		return fmt.Errorf("GITHUB_OUTPUT is not set")
+	}
+
+	var b strings.Builder
+	for _, o := range outputs {
+		if strings.ContainsAny(o.Value, "\r\n") {
+			// multiline values are delimited by a heredoc
+			fmt.Fprintf(&b, "%v<<APPCENTER_EOF\n%v\nAPPCENTER_EOF\n", o.Name, o.Value)
+		} else {
+			fmt.Fprintf(&b, "%v=%v\n", o.Name, o.Value)
+		}
+	}
+
+	return appendFile(path, b.String())
+}
+
+func (g gitHub) Summary(markdown string) error {
+	path := g.env.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return fmt.Errorf("GITHUB_STEP_SUMMARY is not set")
+	}
+
+	return appendFile(path, markdown)
+}
@@ -0,0 +1,39 @@
+package ci
+
+import (
+	"goappcenter/appcenter"
+	"io/ioutil"
+	"strings"
+)
+
+// dotenvFile is the dotenv report written for GitLab CI, to be declared in the job artifacts:
+//
+//	artifacts:
+//	  reports:
+//	    dotenv: appcenter.env
+const dotenvFile = "appcenter.env"
+
+// gitLab writes the variables to a dotenv report in the project directory, passed by GitLab to
+// the next jobs. GitLab has no job summary, the Markdown summary is written next to it.
+type gitLab struct {
+	env Environment
+}
+
+func newGitLab(e Environment) System {
+	return gitLab{env: e}
+}
+
+func (g gitLab) Name() string {
+	return "GitLab CI"
+}
+
+func (g gitLab) SetOutputs(outputs []appcenter.SummaryOutput) error {
+	// dotenv reports do not support quotes nor multiline values
+	return writeVariables(g.env.path("CI_PROJECT_DIR", dotenvFile), outputs, func(v string) string {
+		return strings.NewReplacer("\r", "", "\n", " ").Replace(v)
+	})
+}
+
+func (g gitLab) Summary(markdown string) error {
+	return ioutil.WriteFile(g.env.path("CI_PROJECT_DIR", summaryFile), []byte(markdown), 0644)
+}
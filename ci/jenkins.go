@@ -0,0 +1,38 @@
+package ci
+
+import (
+	"goappcenter/appcenter"
+	"io/ioutil"
+	"strings"
+)
+
+// propertiesFile is the properties file written for Jenkins, to be read by the pipeline with
+// readProperties or injected with the EnvInject plugin
+const propertiesFile = "appcenter.properties"
+
+// jenkins writes the variables to a properties file and the summary to a Markdown file in the
+// workspace, Jenkins having neither step outputs nor job summaries
+type jenkins struct {
+	env Environment
+}
+
+func newJenkins(e Environment) System {
+	return jenkins{env: e}
+}
+
+func (j jenkins) Name() string {
+	return "Jenkins"
+}
+
+func (j jenkins) SetOutputs(outputs []appcenter.SummaryOutput) error {
+	return writeVariables(j.env.path("WORKSPACE", propertiesFile), outputs, propertiesEscape)
+}
+
+func (j jenkins) Summary(markdown string) error {
+	return ioutil.WriteFile(j.env.path("WORKSPACE", summaryFile), []byte(markdown), 0644)
+}
+
+// propertiesEscape escapes the values of Java properties files
+func propertiesEscape(v string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`, ":", `\:`, "=", `\=`).Replace(v)
+}
@@ -0,0 +1,71 @@
+package ci
+
+import (
+	"fmt"
+	"goappcenter/appcenter"
+	"strconv"
+	"strings"
+)
+
+// Markdown returns the job summary of the uploads: a row per upload with the release, its
+// version, destinations and install link
+func Markdown(summaries []*appcenter.UploadSummary) string {
+	var b strings.Builder
+
+	b.WriteString("### AppCenter upload\n\n")
+	b.WriteString("| App | Release | Version | Destinations | Install | Status |\n")
+	b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+
+	for _, s := range summaries {
+		release := appcenter.ReleaseDetails{}
+		if s.Release != nil {
+			release = *s.Release
+		}
+
+		id := ""
+		if s.ReleaseID > 0 {
+			id = strconv.FormatInt(s.ReleaseID, 10)
+		}
+
+		version := release.ShortVersion
+		if release.Version != "" && release.Version != release.ShortVersion {
+			version = fmt.Sprintf("%v (%v)", release.ShortVersion, release.Version)
+		}
+
+		destinations := []string{}
+		for _, d := range s.Distributions {
+			destinations = append(destinations, d.Name)
+		}
+
+		install := ""
+		if release.InstallURL != "" {
+			install = fmt.Sprintf("[Install](%v)", release.InstallURL)
+		}
+
+		status := "✅ Uploaded"
+		if s.Error != "" {
+			status = "❌ " + s.Error
+		}
+
+		cells := []string{
+			release.AppDisplayName,
+			id,
+			version,
+			strings.Join(destinations, ", "),
+			install,
+			status,
+		}
+		for i, c := range cells {
+			cells[i] = markdownEscape(c)
+		}
+
+		fmt.Fprintf(&b, "| %v |\n", strings.Join(cells, " | "))
+	}
+
+	return b.String()
+}
+
+// markdownEscape escapes the content of a table cell
+func markdownEscape(v string) string {
+	return strings.NewReplacer("|", `\|`, "\r", "", "\n", " ").Replace(v)
+}
@@ -0,0 +1,49 @@
+package ci
+
+import (
+	"fmt"
+	"goappcenter/appcenter"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// teamCity sets the build parameters with service messages, and publishes the summary as a
+// build artifact
+type teamCity struct {
+	env Environment
+}
+
+func newTeamCity(e Environment) System {
+	return teamCity{env: e}
+}
+
+func (t teamCity) Name() string {
+	return "TeamCity"
+}
+
+func (t teamCity) SetOutputs(outputs []appcenter.SummaryOutput) error {
+	for _, o := range outputs {
+		if _, err := fmt.Fprintf(t.env.Messages, "##teamcity[setParameter name='env.%v' value='%v']\n",
+			variableName(o.Name), teamCityEscape(o.Value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t teamCity) Summary(markdown string) error {
+	path := t.env.path("TEAMCITY_BUILD_CHECKOUTDIR", summaryFile)
+	if err := ioutil.WriteFile(path, []byte(markdown), 0644); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(t.env.Messages, "##teamcity[publishArtifacts '%v']\n", teamCityEscape(filepath.ToSlash(path)))
+	return err
+}
+
+// teamCityEscape escapes the values of the service messages
+func teamCityEscape(v string) string {
+	return strings.NewReplacer("|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]").Replace(v)
+}
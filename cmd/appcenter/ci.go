@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"goappcenter/appcenter"
+	"goappcenter/ci"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	ciAuto = "auto"
+	ciNone = "none"
+)
+
+// ciFlag selects the CI system the upload result is exported to
+func ciFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "ci",
+		Value: ciAuto,
+		Usage: fmt.Sprintf("CI system to export the release outputs and job summary to: auto, none, %v",
+			strings.Join(ci.Names(), ", ")),
+	}
+}
+
+// exportToCI exports the outputs of a single upload and the job summary to the CI system running
+// the job. A failure is only reported as a warning when the system was detected. The service
+// messages go to the standard error when the standard output holds the upload result.
+func exportToCI(c *cli.Context, summaries []*appcenter.UploadSummary) error {
+	env := ci.DefaultEnvironment(resultOnStdout(c))
+
+	var system ci.System
+	switch name := c.String("ci"); name {
+	case ciNone:
+		return nil
+	case ciAuto:
+		if system = ci.Detect(env); system == nil {
+			return nil
+		}
+	default:
+		s, err := ci.Named(name, env)
+		if err != nil {
+			return err
+		}
+		system = s
+	}
+
+	err := writeToCI(system, summaries)
+	if err != nil && c.String("ci") == ciAuto {
+		log.Warn().Err(err).Str("CI", system.Name()).Msg("Failed to export the upload result")
+		return nil
+	}
+
+	return err
+}
+
+func writeToCI(system ci.System, summaries []*appcenter.UploadSummary) error {
+	// the outputs are ambiguous when several binaries are uploaded, only the summary is written
+	if len(summaries) == 1 {
+		if err := system.SetOutputs(summaries[0].Outputs()); err != nil {
+			return err
+		}
+	}
+
+	if err := system.Summary(ci.Markdown(summaries)); err != nil {
+		return err
+	}
+
+	log.Info().Str("CI", system.Name()).Msg("Upload result exported")
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"goappcenter/appcenter"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+// testContext returns the context of a command with the flags, parsed from the arguments
+func testContext(t *testing.T, flags []cli.Flag, args ...string) *cli.Context {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	for _, f := range flags {
+		assert.NoError(t, f.Apply(set))
+	}
+	assert.NoError(t, set.Parse(args))
+
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+// captureOutput runs fn with the standard output and error redirected to files, and returns
+// their content
+func captureOutput(t *testing.T, fn func()) (string, string) {
+	dir, err := ioutil.TempDir("", "appcenter")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	stdout, err := ioutil.TempFile(dir, "stdout")
+	assert.NoError(t, err)
+	defer stdout.Close()
+	stderr, err := ioutil.TempFile(dir, "stderr")
+	assert.NoError(t, err)
+	defer stderr.Close()
+
+	defer func(stdout, stderr *os.File) { os.Stdout, os.Stderr = stdout, stderr }(os.Stdout, os.Stderr)
+	os.Stdout, os.Stderr = stdout, stderr
+
+	fn()
+
+	out, err := ioutil.ReadFile(stdout.Name())
+	assert.NoError(t, err)
+	errOut, err := ioutil.ReadFile(stderr.Name())
+	assert.NoError(t, err)
+
+	return string(out), string(errOut)
+}
+
+func TestExportToCIServiceMessages(t *testing.T) {
+	flags := append(uploadOutputFlags(), ciFlag())
+	summaries := []*appcenter.UploadSummary{{ReleaseID: 12, Timings: []appcenter.StageTiming{}}}
+
+	// the summary uploaded to Azure Pipelines is written in the agent temporary directory
+	dir, err := ioutil.TempDir("", "appcenter")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	defer os.Unsetenv("AGENT_TEMPDIRECTORY")
+	assert.NoError(t, os.Setenv("AGENT_TEMPDIRECTORY", dir))
+
+	for name, tc := range map[string]struct {
+		args   []string
+		stdout bool
+	}{
+		"table output":     {[]string{"--ci", "azure"}, true},
+		"json output":      {[]string{"--ci", "azure", "--output", "json"}, false},
+		"json output file": {[]string{"--ci", "azure", "--output", "json", "--output-file", "result.json"}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := testContext(t, flags, tc.args...)
+
+			stdout, stderr := captureOutput(t, func() {
+				assert.NoError(t, exportToCI(c, summaries))
+			})
+
+			messages, other := stdout, stderr
+			if !tc.stdout {
+				messages, other = stderr, stdout
+			}
+			assert.Contains(t, messages, "##vso[task.setvariable variable=APPCENTER_RELEASE_ID;isOutput=true]12")
+			assert.NotContains(t, other, "##vso")
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"goappcenter/appcenter"
+	"goappcenter/completion"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// completionTimeout bounds the AppCenter requests of the dynamic completion
+const completionTimeout = 5 * time.Second
+
+func completionCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "completion",
+		Description: "Print the completion script of the shell, ex: source <(go-appcenter completion bash)",
+		ArgsUsage:   strings.Join(completion.Shells, "|"),
+		Action:      executeCompletion,
+	}
+}
+
+func executeCompletion(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cli.Exit(fmt.Sprintf("Expecting the shell: %v", strings.Join(completion.Shells, ", ")), exitValidation)
+	}
+
+	script, err := completion.Script(c.Args().First(), filepath.Base(os.Args[0]))
+	if err != nil {
+		return cli.Exit(err.Error(), exitValidation)
+	}
+
+	_, err = fmt.Fprint(c.App.Writer, script)
+	return err
+}
+
+// setupCompletion completes the values of --ownerName, --appName and --groupName from AppCenter
+// for the commands and their subcommands, the other words being completed by default
+func setupCompletion(commands []*cli.Command) {
+	for _, cmd := range commands {
+		cmd.BashComplete = completeValues(cmd)
+		setupCompletion(cmd.Subcommands)
+	}
+}
+
+func completeValues(cmd *cli.Command) cli.BashCompleteFunc {
+	fallback := cli.DefaultCompleteWithFlags(cmd)
+
+	return func(c *cli.Context) {
+		// the word before the completed one, os.Args ending with --generate-bash-completion
+		if len(os.Args) > 2 {
+			if values := dynamicValues(c, strings.TrimLeft(os.Args[len(os.Args)-2], "-")); values != nil {
+				for _, v := range values {
+					fmt.Fprintln(c.App.Writer, v)
+				}
+				return
+			}
+		}
+
+		fallback(c)
+	}
+}
+
+// dynamicValues returns the values of the flag listed by AppCenter, or nil when the flag is not
+// completed dynamically. Errors only disable the completion.
+func dynamicValues(ctx context.Context, flag string) []string {
+	if APIKey == "" || (flag != "ownerName" && flag != "appName" && flag != "groupName") {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	client := appcenter.NewClient(APIKey)
+	cache := completion.DefaultCache()
+	owner := argValue("ownerName", "AppCenterOwnerName")
+
+	var values []string
+	var err error
+
+	switch flag {
+	case "ownerName":
+		values, err = cache.Values(completion.Key(APIKey, "owners"), func() ([]string, error) {
+			return listOwners(ctx, client)
+		})
+
+	case "appName":
+		values, err = cache.Values(completion.Key(APIKey, "apps", owner), func() ([]string, error) {
+			return listApps(ctx, client, owner)
+		})
+
+	case "groupName":
+		app := argValue("appName", "AppCenterAppName")
+		if owner == "" || app == "" {
+			return []string{}
+		}
+
+		values, err = cache.Values(completion.Key(APIKey, "groups", owner, app), func() ([]string, error) {
+			groups, err := client.WithApp(owner, app).Distribute.Groups(ctx)
+			names := []string{}
+			for _, g := range groups {
+				names = append(names, g.Name)
+			}
+			return names, err
+		})
+	}
+
+	if err != nil {
+		return []string{}
+	}
+
+	return values
+}
+
+// listOwners returns the organizations of the user, and the owners of the applications the token
+// can access (the user itself for its personal applications)
+func listOwners(ctx context.Context, client *appcenter.Client) ([]string, error) {
+	orgs, err := client.Apps.Organizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := client.Apps.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := map[string]bool{}
+	for _, o := range orgs {
+		owners[o.Name] = true
+	}
+	for _, a := range apps {
+		if a.Owner != nil {
+			owners[a.Owner.Name] = true
+		}
+	}
+
+	return sortedKeys(owners), nil
+}
+
+// listApps returns the applications of the owner, or all the applications the token can access
+// when the owner is not known yet
+func listApps(ctx context.Context, client *appcenter.Client, owner string) ([]string, error) {
+	apps, err := client.Apps.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, a := range apps {
+		if owner == "" || (a.Owner != nil && a.Owner.Name == owner) {
+			names[a.Name] = true
+		}
+	}
+
+	return sortedKeys(names), nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// argValue returns the value of the flag typed so far on the command line, or the value of the
+// environment variable. The flags are not parsed yet when completing.
+func argValue(flag string, envVar string) string {
+	args := os.Args[1:]
+	for i, a := range args {
+		name := strings.TrimLeft(a, "-")
+		if name == a {
+			continue
+		}
+
+		if strings.HasPrefix(name, flag+"=") {
+			return strings.TrimPrefix(name, flag+"=")
+		}
+
+		if name == flag && i+1 < len(args) && args[i+1] != "--generate-bash-completion" {
+			return args[i+1]
+		}
+	}
+
+	return os.Getenv(envVar)
+}
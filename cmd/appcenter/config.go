@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"goappcenter/appcenter"
+	"goappcenter/config"
+	"io/ioutil"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// configFlag selects the project configuration file
+func configFlag() cli.Flag {
+	return &cli.PathFlag{
+		Name:    "config",
+		EnvVars: []string{"AppCenterConfig"},
+		Usage:   fmt.Sprintf("Configuration file (default: %v in the working directory)", strings.Join(config.Files, ", ")),
+	}
+}
+
+// targetFlags are the flags selecting a target of the project configuration file
+func targetFlags() []cli.Flag {
+	return []cli.Flag{
+		configFlag(),
+		&cli.StringFlag{
+			Name:    "target",
+			EnvVars: []string{"AppCenterTarget"},
+			Usage:   "Target of the configuration file to upload to (ex: ios-beta)",
+		},
+	}
+}
+
+func configCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "config",
+		Description: "Manage the project configuration file",
+		Subcommands: []*cli.Command{
+			{
+				Name:        "validate",
+				Description: "Check the targets of the configuration file",
+				Flags:       []cli.Flag{configFlag()},
+				Action:      executeConfigValidate,
+			},
+		},
+	}
+}
+
+// loadConfig returns the configuration file selected by --config, or the one of the working
+// directory. It returns nil if there is none.
+func loadConfig(c *cli.Context) (*config.Config, error) {
+	path := c.String("config")
+	if path == "" {
+		if path = config.Find("."); path == "" {
+			return nil, nil
+		}
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, appcenter.NewAppCenterError(appcenter.InputFileError, err)
+	}
+
+	return cfg, nil
+}
+
+// applyTarget completes the upload request with the settings of the target selected by --target,
+// or with the defaults of the configuration file. The flags and environment variables take
+// precedence over the file. It returns the patterns of the binaries to upload.
+func applyTarget(c *cli.Context) ([]string, error) {
+	files := c.StringSlice("file")
+
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg == nil {
+		if c.String("target") != "" {
+			return nil, cli.Exit("'--target' requires a configuration file", exitValidation)
+		}
+		return files, nil
+	}
+
+	t, err := cfg.Target(c.String("target"))
+	if err != nil {
+		return nil, appcenter.NewAppCenterError(appcenter.InputFileError, err)
+	}
+
+	str := func(flag string, dst *string, v string) {
+		if !c.IsSet(flag) && v != "" {
+			*dst = v
+		}
+	}
+	str("ownerName", &request.OwnerName, t.OwnerName)
+	str("appName", &request.AppName, t.AppName)
+	str("buildVersion", &request.Option.BuildVersion, t.BuildVersion)
+	str("buildNumber", &request.Option.BuildNumber, t.BuildNumber)
+	str("symbols", &request.Symbols, cfg.SymbolsDir(t))
+
+	d := &request.Distribute
+
+	// the destinations are replaced as a whole
+	if !c.IsSet("groupName") && !c.IsSet("tester") && !c.IsSet("storeName") &&
+		(len(t.GroupNames) > 0 || len(t.Testers) > 0 || len(t.StoreNames) > 0) {
+		d.GroupNames, d.Testers, d.StoreNames = t.GroupNames, t.Testers, t.StoreNames
+	}
+
+	boolean := func(flag string, dst *bool, v *bool) {
+		if !c.IsSet(flag) && v != nil {
+			*dst = *v
+		}
+	}
+	boolean("mandatory", &d.MandatoryUpdate, t.Mandatory)
+	boolean("notify", &d.NotifyTesters, t.Notify)
+	boolean("waitForStore", &d.WaitForStores, t.WaitForStore)
+
+	if !c.IsSet("rolloutFraction") && t.RolloutFraction != nil {
+		d.RolloutFraction = *t.RolloutFraction
+	}
+
+	if !c.IsSet("releaseNotes") && !c.IsSet("releaseNotesFile") {
+		if request.ReleaseNotes, err = cfg.Notes(t); err != nil {
+			return nil, appcenter.NewAppCenterError(appcenter.InputFileError, err)
+		}
+	}
+
+	if len(files) == 0 && t.File != "" {
+		files = []string{cfg.FilePattern(t)}
+	}
+
+	return files, nil
+}
+
+// releaseNotes reads the release notes provided with --releaseNotesFile
+func releaseNotes(c *cli.Context) error {
+	path := c.String("releaseNotesFile")
+	if path == "" {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return appcenter.NewAppCenterError(appcenter.InputFileError, err)
+	}
+
+	request.ReleaseNotes = strings.TrimSpace(string(data))
+	return nil
+}
+
+func executeConfigValidate(c *cli.Context) error {
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+
+	if cfg == nil {
+		return cli.Exit(fmt.Sprintf("No configuration file found (%v)", strings.Join(config.Files, ", ")), exitValidation)
+	}
+
+	rows := [][]string{}
+	for _, name := range cfg.Names() {
+		t, _ := cfg.Target(name)
+
+		destinations := append(append(append([]string{}, t.GroupNames...), t.Testers...), t.StoreNames...)
+		rows = append(rows, []string{
+			name,
+			fmt.Sprintf("%v/%v", t.OwnerName, t.AppName),
+			t.File,
+			strings.Join(destinations, ", "),
+		})
+	}
+
+	if err := renderTable([]string{"Target", "App", "File", "Destinations"}, rows); err != nil {
+		return err
+	}
+
+	problems := cfg.Validate()
+	for _, p := range problems {
+		appcenter.DefaultUI.Error(p.Error())
+	}
+
+	if len(problems) > 0 {
+		return cli.Exit(fmt.Sprintf("%d problems found in `%v`", len(problems), cfg.Path), exitValidation)
+	}
+
+	appcenter.DefaultUI.Success(fmt.Sprintf("Configuration `%v` is valid", cfg.Path))
+	return nil
+}
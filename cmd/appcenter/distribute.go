@@ -0,0 +1,74 @@
+package main
+
+import (
+	"goappcenter/appcenter"
+
+	"github.com/pterm/pterm"
+	"github.com/urfave/cli/v2"
+)
+
+// distributionFlags are the flags describing the destinations of a release
+func distributionFlags() []cli.Flag {
+	return append([]cli.Flag{
+		&cli.StringSliceFlag{
+			EnvVars: []string{"groupName"},
+			Name:    "groupName",
+			Usage:   "Group name to distribute to the release (can be repeated)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "tester",
+			Usage: "Email of a tester to distribute the release to (can be repeated)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "storeName",
+			Usage: "Store name to distribute the release to (can be repeated)",
+		},
+		&cli.BoolFlag{
+			Name:  "mandatory",
+			Usage: "Flag the release as a mandatory update",
+		},
+		&cli.BoolFlag{
+			Name:  "notify",
+			Usage: "Notify the testers about the release",
+		},
+	}, publishFlags()...)
+}
+
+func distributionPayload(c *cli.Context) appcenter.DistributionPayload {
+	opts := publishOptions(c)
+
+	return appcenter.DistributionPayload{
+		GroupNames:      c.StringSlice("groupName"),
+		Testers:         c.StringSlice("tester"),
+		StoreNames:      c.StringSlice("storeName"),
+		MandatoryUpdate: c.Bool("mandatory"),
+		NotifyTesters:   c.Bool("notify"),
+		WaitForStores:   opts.Wait,
+		RolloutFraction: opts.RolloutFraction,
+	}
+}
+
+func distributeCommand() *cli.Command {
+	flags := append(appFlags(), releaseQueryFlags()...)
+
+	return &cli.Command{
+		Name:        "distribute",
+		Description: "Distribute an already uploaded release to groups, testers or stores",
+		Flags:       append(flags, distributionFlags()...),
+		Action:      executeDistribute,
+	}
+}
+
+func executeDistribute(c *cli.Context) error {
+	pterm.DefaultHeader.Println("GO AppCenter")
+
+	client := newClient(c)
+
+	p := distributionPayload(c)
+	if p.IsEmpty() {
+		return cli.Exit("At least one group, tester or store must be provided", exitValidation)
+	}
+
+	_, err := client.Distribute.Redistribute(c, releaseQuery(c), p)
+	return err
+}
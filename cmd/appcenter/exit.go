@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"goappcenter/appcenter"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Exit codes of the command line, part of its contract with the scripts and CI pipelines
+const (
+	exitOK             = 0
+	exitFailure        = 1
+	exitValidation     = 2
+	exitAuthentication = 3
+	exitUpload         = 4
+	exitProcessing     = 5
+	exitDistribution   = 6
+	exitTimeout        = 7
+)
+
+var exitCodes = map[appcenter.ErrorKind]int{
+	appcenter.KindValidation:     exitValidation,
+	appcenter.KindAuthentication: exitAuthentication,
+	appcenter.KindUpload:         exitUpload,
+	appcenter.KindProcessing:     exitProcessing,
+	appcenter.KindDistribution:   exitDistribution,
+	appcenter.KindTimeout:        exitTimeout,
+}
+
+// exitCodesHelp documents the exit codes in the help of the command line
+var exitCodesHelp = fmt.Sprintf(`Exit codes:
+   %d  success
+   %d  unexpected error, invalid command line
+   %d  validation error: input file, arguments or binary not matching the application
+   %d  authentication failure: invalid API token or access denied
+   %d  upload failure of the binary or its symbols
+   %d  processing error: the binary was rejected by AppCenter
+   %d  distribution failure to a group, tester or store
+   %d  timeout while waiting for AppCenter`,
+	exitOK, exitFailure, exitValidation, exitAuthentication, exitUpload, exitProcessing, exitDistribution, exitTimeout)
+
+// ignoreExitErrors replaces the default handler of the errors, which exits from the middle of
+// app.Run: the errors are returned to main, which logs them, closes the log file and exits with
+// their exitCode
+func ignoreExitErrors(*cli.Context, error) {}
+
+// exitCode returns the exit code of the error
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	var e cli.ExitCoder
+	if errors.As(err, &e) {
+		return e.ExitCode()
+	}
+
+	if code, ok := exitCodes[appcenter.KindOf(err)]; ok {
+		return code
+	}
+
+	return exitFailure
+}
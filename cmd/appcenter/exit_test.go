@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goappcenter/appcenter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+func TestExitCode(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		code int
+	}{
+		"success":           {nil, exitOK},
+		"unexpected error":  {errors.New("failure"), exitFailure},
+		"exit coder":        {cli.Exit("Required flag \"apiKey\" not set", exitValidation), exitValidation},
+		"wrapped exit code": {fmt.Errorf("upload: %w", cli.Exit("2 of 3 uploads failed", exitUpload)), exitUpload},
+		"unauthorized": {
+			appcenter.NewAppCenterError(appcenter.UploadRequestError, &appcenter.StatusError{StatusCode: 401}),
+			exitAuthentication,
+		},
+		"forbidden": {
+			fmt.Errorf("release: %w", appcenter.NewAppCenterError(appcenter.CommitError, &appcenter.StatusError{StatusCode: 403})),
+			exitAuthentication,
+		},
+		"server error": {
+			appcenter.NewAppCenterError(appcenter.CommitError, &appcenter.StatusError{StatusCode: 500}),
+			exitUpload,
+		},
+		"timeout": {appcenter.NewAppCenterError(appcenter.PollingError, context.DeadlineExceeded), exitTimeout},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.code, exitCode(tc.err))
+		})
+	}
+}
+
+func TestExitErrorsReturned(t *testing.T) {
+	app := cli.App{
+		ExitErrHandler: ignoreExitErrors,
+		Commands: []*cli.Command{{
+			Name:   "upload",
+			Action: func(c *cli.Context) error { return cli.Exit("1 of 2 uploads failed", exitUpload) },
+		}},
+	}
+
+	// without the handler, the error would exit the test binary
+	err := app.Run([]string{"go-appcenter", "upload"})
+	assert.EqualError(t, err, "1 of 2 uploads failed")
+	assert.Equal(t, exitUpload, exitCode(err))
+}
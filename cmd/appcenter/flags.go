@@ -0,0 +1,64 @@
+package main
+
+import (
+	"goappcenter/appcenter"
+
+	"github.com/urfave/cli/v2"
+)
+
+// appFlags are the flags designating the AppCenter application to work with
+func appFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			EnvVars:  []string{"AppCenterAppName"},
+			Name:     "appName",
+			Required: true,
+			Usage:    "AppCenter app name",
+		},
+		&cli.StringFlag{
+			EnvVars:  []string{"AppCenterOwnerName"},
+			Name:     "ownerName",
+			Required: true,
+			Usage:    "AppCenter owner name",
+		},
+	}
+}
+
+// newClient creates an AppCenter client for the application designated by the appFlags
+func newClient(c *cli.Context) *appcenter.Client {
+	client := appcenter.NewClient(APIKey)
+	client.Config.AppName = c.String("appName")
+	client.Config.OwnerName = c.String("ownerName")
+	return client
+}
+
+// releaseQueryFlags are the flags selecting an already uploaded release
+func releaseQueryFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.Int64Flag{
+			Name:  "releaseId",
+			Usage: "Release ID",
+		},
+		&cli.StringFlag{
+			Name:  "buildVersion",
+			Usage: "Build version of the release",
+		},
+		&cli.StringFlag{
+			Name:  "buildNumber",
+			Usage: "Build number of the release",
+		},
+		&cli.BoolFlag{
+			Name:  "latest",
+			Usage: "Select the latest release",
+		},
+	}
+}
+
+func releaseQuery(c *cli.Context) appcenter.ReleaseQuery {
+	return appcenter.ReleaseQuery{
+		ID:           c.Int64("releaseId"),
+		BuildVersion: c.String("buildVersion"),
+		BuildNumber:  c.String("buildNumber"),
+		Latest:       c.Bool("latest"),
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	logFormatConsole = "console"
+	logFormatJSON    = "json"
+)
+
+// logFile is the file the logs are written to with --log-file, closed once the error of the
+// command is logged
+var logFile *os.File
+
+// loggingFlags configure the level, format and destination of the logs
+func loggingFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "log-level",
+			EnvVars: []string{"AppCenterLogLevel"},
+			Value:   zerolog.InfoLevel.String(),
+			Usage:   "Level of the logs (trace, debug, info, warn or error)",
+		},
+		&cli.StringFlag{
+			Name:    "log-format",
+			EnvVars: []string{"AppCenterLogFormat"},
+			Value:   logFormatConsole,
+			Usage:   "Format of the logs (console or json)",
+		},
+		&cli.PathFlag{
+			Name:    "log-file",
+			EnvVars: []string{"AppCenterLogFile"},
+			Usage:   "Append the logs to this file instead of the standard error",
+		},
+	}
+}
+
+// setupLogging configures the global logger, which the clients of the commands log to
+func setupLogging(c *cli.Context) error {
+	level, err := zerolog.ParseLevel(strings.ToLower(c.String("log-level")))
+	if err != nil || level == zerolog.NoLevel {
+		return cli.Exit(fmt.Sprintf("Unsupported log level '%v'", c.String("log-level")), exitValidation)
+	}
+
+	format := c.String("log-format")
+	if format != logFormatConsole && format != logFormatJSON {
+		return cli.Exit(fmt.Sprintf("Unsupported log format '%v'", format), exitValidation)
+	}
+
+	var out io.Writer = os.Stderr
+	if path := c.String("log-file"); path != "" {
+		if logFile, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+			return cli.Exit(fmt.Sprintf("Failed to open the log file: %v", err), exitValidation)
+		}
+		out = logFile
+	}
+
+	if format == logFormatConsole {
+		out = zerolog.ConsoleWriter{Out: out, NoColor: logFile != nil || noColor() || !isTerminal(os.Stderr)}
+	}
+
+	log.Logger = zerolog.New(out).Level(level).With().Timestamp().Logger()
+	return nil
+}
+
+// closeLogging closes the log file
+func closeLogging() {
+	if logFile != nil {
+		//nolint:errcheck
+		logFile.Close()
+	}
+}
@@ -19,48 +19,68 @@ var request = appcenter.UploadTask{
 }
 
 func main() {
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
-	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	// replaced by setupLogging once the flags are parsed
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr}).Level(zerolog.InfoLevel)
 
 	app := cli.App{
-		Name:    "go-appcenter",
-		Version: "0.2.0",
+		Name:                 "go-appcenter",
+		Version:              version,
+		EnableBashCompletion: true,
+		ExitErrHandler:       ignoreExitErrors,
 	}
 
-	app.Flags = []cli.Flag{
+	app.Flags = append([]cli.Flag{
 		&cli.StringFlag{
 			Destination: &APIKey,
 			EnvVars:     []string{"AppCenterAPIKey"},
 			Name:        "apiKey",
-			Required:    true,
 			Usage:       "AppCenter.ms API key",
 		},
+	}, append(loggingFlags(), uiFlags()...)...)
+	app.Before = func(c *cli.Context) error {
+		if err := setupLogging(c); err != nil {
+			return err
+		}
+		return setupUI(c)
 	}
 	app.Name = "Golang AppCenter.ms"
 	app.Usage = "Upload and distribute binaries on the AppCenter platform"
+	app.Description = exitCodesHelp
+
+	uploadFlags := distributionFlags()
+	uploadFlags = append(uploadFlags, uploadOutputFlags()...)
+	uploadFlags = append(uploadFlags, targetFlags()...)
+	uploadFlags = append(uploadFlags, ciFlag(), dryRunFlag(), interactiveFlag())
+
 	app.Commands = []*cli.Command{
 		{
 			Name:        "upload",
 			Description: "Upload binary to AppCenter for distribution. And optionally distribute it",
-			Flags: []cli.Flag{
-				&cli.PathFlag{Name: "file",
-					EnvVars:     []string{"AppCenterFileName"},
-					Aliases:     []string{"f"},
-					Destination: &request.FilePath,
-					Required:    true,
+			Flags: append([]cli.Flag{
+				&cli.StringSliceFlag{Name: "file",
+					EnvVars: []string{"AppCenterFileName"},
+					Aliases: []string{"f"},
+					Usage:   "Binary to upload, glob, directory, stdin://<name>?size=<bytes>, http(s):// or s3:// URL (can be repeated)",
+				},
+				&cli.PathFlag{
+					Name:  "manifest",
+					Usage: "YAML or JSON file mapping the binaries to upload to their application and destinations",
+				},
+				&cli.IntFlag{
+					Name:  "parallel",
+					Value: 2,
+					Usage: "Maximum number of binaries uploaded at the same time",
 				},
 				&cli.StringFlag{
 					EnvVars:     []string{"AppCenterAppName"},
 					Destination: &request.AppName,
 					Name:        "appName",
-					Required:    true,
 					Usage:       "AppCenter app name",
 				},
 				&cli.StringFlag{
 					Destination: &request.OwnerName,
 					EnvVars:     []string{"AppCenterOwnerName"},
 					Name:        "ownerName",
-					Required:    true,
 					Usage:       "AppCenter owner name",
 				},
 				&cli.StringFlag{
@@ -82,38 +102,143 @@ func main() {
 					Usage:       "Release version Id",
 				},
 				&cli.StringFlag{
-					Destination: &request.Distribute.GroupName,
-					EnvVars:     []string{"groupName"},
-					Name:        "groupName",
-					Required:    false,
-					Usage:       "Group name to distribute to the release",
+					Destination: &request.ContentType,
+					Name:        "contentType",
+					Usage:       "Content type of the binary, detected from the file by default",
+				},
+				&cli.StringFlag{
+					Destination: &request.Symbols,
+					Name:        "symbols",
+					Usage:       "Directory where the dSYMs matching the IPA are looked up (ex: the .xcarchive), to upload them",
 				},
-			},
+				&cli.StringFlag{
+					Destination: &request.ReleaseNotes,
+					Name:        "releaseNotes",
+					Usage:       "Release notes of the release",
+				},
+				&cli.PathFlag{
+					Name:  "releaseNotesFile",
+					Usage: "File containing the release notes of the release",
+				},
+				&cli.BoolFlag{
+					Destination: &request.Force,
+					Name:        "force",
+					Usage:       "Upload even if the binary does not match the application (platform, bundle identifier, debug build)",
+				},
+			}, uploadFlags...),
 			Action: executeUpload,
 		},
+		distributeCommand(),
+		releasesCommand(),
+		storesCommand(),
+		symbolsCommand(),
+		testersCommand(),
 	}
 
-	if err := app.Run(os.Args); err != nil {
+	// the commands calling the AppCenter API require the API key
+	for _, cmd := range app.Commands {
+		cmd.Before = requireAPIKey
+	}
+	app.Commands = append(app.Commands, configCommand(), completionCommand(), versionCommand(), selfUpdateCommand())
+	setupCompletion(app.Commands)
+
+	err := app.Run(os.Args)
+	if err != nil {
 		log.Error().Err(err).Msg("Error during execution")
 	}
+	closeLogging()
+
+	if err != nil {
+		os.Exit(exitCode(err))
+	}
+}
+
+// requireAPIKey fails when no API key is provided, through --apiKey or the environment
+func requireAPIKey(c *cli.Context) error {
+	if APIKey == "" {
+		return cli.Exit("Required flag \"apiKey\" not set", exitValidation)
+	}
+
+	return nil
 }
 
 func executeUpload(c *cli.Context) error {
+	request.Distribute = distributionPayload(c)
+
+	if err := releaseNotes(c); err != nil {
+		return err
+	}
+
+	files, err := applyTarget(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("interactive") {
+		if files, err = runWizard(c, files); err != nil {
+			return err
+		}
+	}
+
+	tasks, err := uploadTasks(c, files)
+	if err != nil {
+		return err
+	}
+
+	if err := setupUploadOutput(c, len(tasks)); err != nil {
+		return err
+	}
+
 	pterm.DefaultHeader.Println("GO AppCenter")
 
+	if c.Bool("dry-run") {
+		return executeUploadPlan(c, tasks)
+	}
+
+	// a single binary is uploaded as before, reporting its error as is
+	if len(tasks) == 1 && c.String("manifest") == "" {
+		return executeSingleUpload(c, tasks[0])
+	}
+
 	client := appcenter.NewClient(APIKey)
+	results := client.UploadBatch(c, tasks, c.Int("parallel"))
+
+	summaries := []*appcenter.UploadSummary{}
+	for _, r := range results {
+		summaries = append(summaries, r.Summary)
+	}
 
-	client.Config.AppName = request.AppName
-	client.Config.OwnerName = request.OwnerName
+	if err := writeUploadOutput(c, summaries); err != nil {
+		return err
+	}
 
-	releaseID, err := client.Upload.Do(c, request)
-	if err != nil {
+	if err := exportToCI(c, summaries); err != nil {
 		return err
 	}
 
-	if request.Distribute.GroupName != "" {
-		return client.Distribute.Do(c, releaseID, request)
+	return renderBatchResults(results)
+}
+
+func executeSingleUpload(c *cli.Context, task appcenter.UploadTask) error {
+	if task.OwnerName == "" || task.AppName == "" {
+		return cli.Exit("'--ownerName' and '--appName' must be provided", exitValidation)
 	}
 
-	return nil
+	client := appcenter.NewClient(APIKey)
+
+	client.Config.AppName = task.AppName
+	client.Config.OwnerName = task.OwnerName
+
+	summary, err := client.Upload.Run(c, task)
+	summaries := []*appcenter.UploadSummary{summary}
+
+	if werr := writeUploadOutput(c, summaries); err == nil {
+		err = werr
+	}
+
+	if cerr := exportToCI(c, summaries); err == nil {
+		err = cerr
+	}
+
+	return err
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputCSV   = "csv"
+)
+
+// outputFlag selects the format of the command result
+func outputFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "output",
+		Aliases: []string{"o"},
+		Value:   outputTable,
+		Usage:   "Output format of the result (table, json or csv)",
+	}
+}
+
+// setupOutput validates the output format and, for machine readable formats, moves the progress
+// reporting to the standard error so the standard output only contains the result
+func setupOutput(c *cli.Context) error {
+	switch c.String("output") {
+	case outputTable:
+	case outputJSON, outputCSV:
+		progressToStderr(c)
+	default:
+		return fmt.Errorf("Unsupported output format '%v'", c.String("output"))
+	}
+
+	return nil
+}
+
+// writeOutput writes the result in the format selected by the outputFlag. The value is used for
+// the JSON format, the header and rows for the table and CSV formats
+func writeOutput(c *cli.Context, v interface{}, header []string, rows [][]string) error {
+	switch c.String("output") {
+	case outputJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+
+	case outputCSV:
+		w := csv.NewWriter(os.Stdout)
+		if err := w.Write(header); err != nil {
+			return err
+		}
+		return w.WriteAll(rows)
+
+	default:
+		return renderTable(header, rows)
+	}
+}
+
+// renderTable writes the table to the standard output, bypassing the pterm output which --quiet
+// discards
+func renderTable(header []string, rows [][]string) error {
+	s, err := pterm.DefaultTable.WithHasHeader().WithData(append([][]string{header}, rows...)).Srender()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, s)
+	return err
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// prompter asks questions on the terminal, the questions being written to the standard error so
+// the standard output only contains the command result. The answers are read line by line, pterm
+// v0.12.8 having no interactive prompts.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// option is a choice of a prompt: its value and the label shown to the user
+type option struct {
+	value string
+	label string
+}
+
+func newPrompter() (*prompter, error) {
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if fi.Mode()&os.ModeCharDevice == 0 {
+		return nil, fmt.Errorf("The interactive mode requires a terminal")
+	}
+
+	return &prompter{in: bufio.NewReader(os.Stdin), out: os.Stderr}, nil
+}
+
+// input asks for a value, the default one being used when the answer is empty
+func (p *prompter) input(label string, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%v [%v]: ", label, def)
+	} else {
+		fmt.Fprintf(p.out, "%v: ", label)
+	}
+
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("No answer to '%v'", label)
+	}
+
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+
+	return def, nil
+}
+
+// required asks for a value until a non empty one is provided
+func (p *prompter) required(label string, def string) (string, error) {
+	for {
+		v, err := p.input(label, def)
+		if err != nil || v != "" {
+			return v, err
+		}
+	}
+}
+
+// choose asks to pick one of the options by number. Another value can be typed, for the values
+// missing from the list.
+func (p *prompter) choose(label string, options []option, def string) (string, error) {
+	p.list(label, options)
+
+	v, err := p.required(label, def)
+	if err != nil {
+		return "", err
+	}
+
+	return p.resolve(v, options), nil
+}
+
+// chooseMany asks to pick any number of the options, by number or value, separated by commas.
+// The default values are kept when the answer is empty, and "-" selects none.
+func (p *prompter) chooseMany(label string, options []option, defs []string) ([]string, error) {
+	p.list(label, options)
+
+	v, err := p.input(label+" (comma separated, - for none)", strings.Join(defs, ","))
+	if err != nil || v == "" || v == "-" {
+		return []string{}, err
+	}
+
+	values := []string{}
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			values = append(values, p.resolve(s, options))
+		}
+	}
+
+	return values, nil
+}
+
+// confirm asks a yes or no question
+func (p *prompter) confirm(label string, def bool) (bool, error) {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+
+	for {
+		v, err := p.input(fmt.Sprintf("%v (%v)", label, choices), "")
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(v) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+func (p *prompter) list(label string, options []option) {
+	if len(options) == 0 {
+		return
+	}
+
+	fmt.Fprintf(p.out, "%v:\n", label)
+	for i, o := range options {
+		fmt.Fprintf(p.out, "  %2d) %v\n", i+1, o.label)
+	}
+}
+
+// resolve returns the value of the option of the number, or the answer itself
+func (p *prompter) resolve(answer string, options []option) string {
+	if i, err := strconv.Atoi(answer); err == nil && i >= 1 && i <= len(options) {
+		return options[i-1].value
+	}
+
+	return answer
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testPrompter returns a prompter reading the answers, and the buffer receiving the questions
+func testPrompter(answers ...string) (*prompter, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	var lines strings.Builder
+	for _, a := range answers {
+		lines.WriteString(a + "\n")
+	}
+	in := bufio.NewReader(strings.NewReader(lines.String()))
+
+	return &prompter{in: in, out: out}, out
+}
+
+func TestPrompterInput(t *testing.T) {
+	p, out := testPrompter("  value  ", "")
+
+	v, err := p.input("Name", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", v)
+	assert.Equal(t, "Name: ", out.String())
+
+	v, err = p.input("Version", "1.2.3")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", v, "the default value is used for an empty answer")
+	assert.Equal(t, "Name: Version [1.2.3]: ", out.String())
+
+	_, err = p.input("Build", "")
+	assert.EqualError(t, err, "No answer to 'Build'")
+}
+
+func TestPrompterRequired(t *testing.T) {
+	p, out := testPrompter("", "", "app")
+
+	v, err := p.required("Target name", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "app", v)
+	assert.Equal(t, 3, strings.Count(out.String(), "Target name: "))
+}
+
+func TestPrompterChoose(t *testing.T) {
+	options := []option{{"owner", "Owner (owner)"}, {"org", "Organization (org)"}}
+
+	for name, tc := range map[string]struct {
+		answer   string
+		def      string
+		expected string
+	}{
+		"number":        {"2", "", "org"},
+		"typed value":   {"other", "", "other"},
+		"out of range":  {"3", "", "3"},
+		"default value": {"", "owner", "owner"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, out := testPrompter(tc.answer)
+
+			v, err := p.choose("Owner", options, tc.def)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, v)
+			assert.Contains(t, out.String(), "Owner:\n   1) Owner (owner)\n   2) Organization (org)\n")
+		})
+	}
+}
+
+func TestPrompterChooseMany(t *testing.T) {
+	options := []option{{"Beta", "Beta"}, {"QA", "QA"}, {"Collaborators", "Collaborators"}}
+
+	for name, tc := range map[string]struct {
+		answer   string
+		defs     []string
+		expected []string
+	}{
+		"numbers and values": {"1, Public ,3", nil, []string{"Beta", "Public", "Collaborators"}},
+		"default values":     {"", []string{"QA"}, []string{"QA"}},
+		"none":               {"-", []string{"QA"}, []string{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, _ := testPrompter(tc.answer)
+
+			v, err := p.chooseMany("Distribution groups", options, tc.defs)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, v)
+		})
+	}
+}
+
+func TestPrompterConfirm(t *testing.T) {
+	for name, tc := range map[string]struct {
+		answers  []string
+		def      bool
+		expected bool
+	}{
+		"yes":             {[]string{"Y"}, false, true},
+		"no":              {[]string{"no"}, true, false},
+		"default":         {[]string{""}, true, true},
+		"invalid answers": {[]string{"maybe", "yes"}, false, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, out := testPrompter(tc.answers...)
+
+			v, err := p.confirm("Save?", tc.def)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, v)
+			assert.Equal(t, len(tc.answers), strings.Count(out.String(), "Save? ("))
+		})
+	}
+
+	p, _ := testPrompter()
+	_, err := p.confirm("Save?", true)
+	assert.Error(t, err, "a closed input is not a confirmation")
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"goappcenter/appcenter"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+func releasesCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "releases",
+		Description: "Manage the releases of an application",
+		Subcommands: []*cli.Command{
+			{
+				Name:        "list",
+				Description: "List the releases of an application",
+				Flags:       append(appFlags(), outputFlag()),
+				Action:      executeReleasesList,
+			},
+			{
+				Name:        "prune",
+				Description: "Delete the releases not retained by the retention policy",
+				Flags: append(appFlags(),
+					&cli.IntFlag{
+						Name:  "keepLast",
+						Usage: "Keep the N most recent releases",
+					},
+					&cli.DurationFlag{
+						Name:  "keepNewerThan",
+						Usage: "Keep the releases uploaded more recently than this duration (ex: 720h)",
+					},
+					&cli.StringSliceFlag{
+						Name:  "keepDestination",
+						Usage: "Keep the releases distributed to this group or store (can be repeated)",
+					},
+					&cli.BoolFlag{
+						Name:  "dryRun",
+						Usage: "Only print the releases that would be deleted",
+					},
+					&cli.BoolFlag{
+						Name:    "yes",
+						Aliases: []string{"y"},
+						Usage:   "Do not ask for confirmation before deleting",
+					},
+				),
+				Action: executeReleasesPrune,
+			},
+		},
+	}
+}
+
+func executeReleasesList(c *cli.Context) error {
+	if err := setupOutput(c); err != nil {
+		return err
+	}
+
+	releases, err := newClient(c).Releases.List(c)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(c, releases, releasesHeader, releasesRows(releases))
+}
+
+func executeReleasesPrune(c *cli.Context) error {
+	policy := appcenter.RetentionPolicy{
+		KeepLast:         c.Int("keepLast"),
+		KeepNewerThan:    c.Duration("keepNewerThan"),
+		KeepDestinations: c.StringSlice("keepDestination"),
+	}
+
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	client := newClient(c)
+
+	releases, err := client.Releases.List(c)
+	if err != nil {
+		return err
+	}
+
+	keep, prune := policy.Apply(releases, time.Now())
+	if len(prune) == 0 {
+		appcenter.DefaultUI.Info(fmt.Sprintf("Nothing to prune, %d releases retained", len(keep)))
+		return nil
+	}
+
+	if err := renderTable(releasesHeader, releasesRows(prune)); err != nil {
+		return err
+	}
+
+	appcenter.DefaultUI.Info(fmt.Sprintf("%d releases retained, %d releases to delete", len(keep), len(prune)))
+
+	if c.Bool("dryRun") {
+		return nil
+	}
+
+	if !c.Bool("yes") && !confirm(fmt.Sprintf("Delete %d releases?", len(prune))) {
+		appcenter.DefaultUI.Warning("Pruning aborted")
+		return nil
+	}
+
+	return client.Releases.Prune(c, prune)
+}
+
+var releasesHeader = []string{"ID", "Version", "Build", "Uploaded at", "Enabled", "Destinations"}
+
+func releasesRows(releases []appcenter.Release) [][]string {
+	data := [][]string{}
+	for _, r := range releases {
+		names := []string{}
+		for _, d := range r.Destinations {
+			names = append(names, d.Name)
+		}
+
+		data = append(data, []string{
+			strconv.FormatInt(r.ID, 10),
+			r.ShortVersion,
+			r.Version,
+			r.UploadedAt,
+			strconv.FormatBool(r.Enabled),
+			strings.Join(names, ", "),
+		})
+	}
+
+	return data
+}
+
+// confirm asks the user a yes/no question on the standard input
+func confirm(question string) bool {
+	fmt.Printf("%v [y/N] ", question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"goappcenter/appcenter"
+
+	"github.com/urfave/cli/v2"
+)
+
+// publishFlags are the flags of the store publishing options
+func publishFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "waitForStore",
+			Usage: "Wait for the release to be published to the store",
+		},
+		&cli.Float64Flag{
+			Name:  "rolloutFraction",
+			Usage: "Fraction of the users receiving the release (ex: 0.1)",
+		},
+	}
+}
+
+func publishOptions(c *cli.Context) appcenter.PublishOptions {
+	return appcenter.PublishOptions{
+		Wait:            c.Bool("waitForStore"),
+		RolloutFraction: c.Float64("rolloutFraction"),
+	}
+}
+
+func storesCommand() *cli.Command {
+	promoteFlags := append(appFlags(), releaseQueryFlags()...)
+	promoteFlags = append(promoteFlags,
+		&cli.StringFlag{
+			Name:     "fromTrack",
+			Required: true,
+			Usage:    "Google Play track the release is published to (internal, alpha, beta)",
+		},
+		&cli.StringFlag{
+			Name:  "toTrack",
+			Usage: "Google Play track to promote the release to (default: the next track)",
+		},
+	)
+
+	rollbackFlags := append(appFlags(),
+		&cli.StringFlag{
+			Name:     "storeName",
+			Required: true,
+			Usage:    "Store to roll back",
+		},
+	)
+
+	return &cli.Command{
+		Name:        "stores",
+		Description: "Manage the stores connected to an application",
+		Subcommands: []*cli.Command{
+			{
+				Name:        "list",
+				Description: "List the stores connected to an application",
+				Flags:       append(appFlags(), outputFlag()),
+				Action:      executeStoresList,
+			},
+			{
+				Name:        "promote",
+				Description: "Promote a release between Google Play tracks (internal, alpha, beta, production)",
+				Flags:       append(promoteFlags, publishFlags()...),
+				Action:      executeStoresPromote,
+			},
+			{
+				Name:        "rollback",
+				Description: "Publish again to a store the release published before its current one",
+				Flags:       append(rollbackFlags, publishFlags()...),
+				Action:      executeStoresRollback,
+			},
+		},
+	}
+}
+
+func executeStoresList(c *cli.Context) error {
+	if err := setupOutput(c); err != nil {
+		return err
+	}
+
+	stores, err := newClient(c).Stores.List(c)
+	if err != nil {
+		return err
+	}
+
+	header := []string{"Name", "Type", "Track", "ID"}
+	rows := [][]string{}
+	for _, s := range stores {
+		rows = append(rows, []string{s.Name, s.Type, s.Track, s.ID})
+	}
+
+	return writeOutput(c, stores, header, rows)
+}
+
+func executeStoresPromote(c *cli.Context) error {
+	client := newClient(c)
+
+	release, err := client.Releases.Resolve(c, releaseQuery(c))
+	if err != nil {
+		return err
+	}
+
+	return client.Stores.Promote(c, release.ID, c.String("fromTrack"), c.String("toTrack"), publishOptions(c))
+}
+
+func executeStoresRollback(c *cli.Context) error {
+	_, err := newClient(c).Stores.Rollback(c, c.String("storeName"), publishOptions(c))
+	return err
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"goappcenter/appcenter"
+	"strconv"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/urfave/cli/v2"
+)
+
+func symbolsCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "symbols",
+		Description: "Manage the crash symbols of an application",
+		Subcommands: []*cli.Command{
+			{
+				Name:        "upload",
+				Description: "Upload dSYM, Android mapping, Breakpad or UWP symbols",
+				Flags: append(appFlags(),
+					&cli.StringFlag{
+						Name:     "type",
+						Required: true,
+						Usage:    "Symbol type: dsym, mapping, breakpad or uwp",
+					},
+					&cli.StringFlag{
+						Name:     "file",
+						Aliases:  []string{"f"},
+						Required: true,
+						Usage:    "Symbols file, or directory zipped before the upload (ex: App.app.dSYM)",
+					},
+					&cli.StringFlag{
+						Name:  "versionName",
+						Usage: "Version name of the release (required for Android mappings)",
+					},
+					&cli.StringFlag{
+						Name:  "versionCode",
+						Usage: "Version code of the release (required for Android mappings)",
+					},
+				),
+				Action: executeSymbolsUpload,
+			},
+			{
+				Name:        "list",
+				Description: "List the symbol uploads of an application with their status",
+				Flags: append(appFlags(), outputFlag(),
+					&cli.StringFlag{
+						Name:  "status",
+						Usage: "Only list the uploads with this status (created, committed, aborted, processing, indexed, failed)",
+					},
+				),
+				Action: executeSymbolsList,
+			},
+			{
+				Name:        "missing",
+				Description: "List the symbols missing to symbolicate the crashes of an application",
+				Flags:       append(appFlags(), outputFlag()),
+				Action:      executeSymbolsMissing,
+			},
+			{
+				Name:        "prune",
+				Description: "Delete the stale symbol uploads (created, aborted or failed by default)",
+				Flags: append(appFlags(),
+					&cli.StringSliceFlag{
+						Name:  "status",
+						Usage: "Delete the uploads with this status (can be repeated)",
+					},
+					&cli.DurationFlag{
+						Name:  "olderThan",
+						Usage: "Only delete the uploads started before this duration (ex: 720h)",
+					},
+					&cli.BoolFlag{
+						Name:  "dryRun",
+						Usage: "Only print the symbol uploads that would be deleted",
+					},
+					&cli.BoolFlag{
+						Name:    "yes",
+						Aliases: []string{"y"},
+						Usage:   "Do not ask for confirmation before deleting",
+					},
+				),
+				Action: executeSymbolsPrune,
+			},
+			{
+				Name:        "ignore",
+				Description: "Ignore missing symbols, the crashes requiring them are no longer reported",
+				Flags: append(appFlags(),
+					&cli.StringSliceFlag{
+						Name:  "symbolId",
+						Usage: "Identifier of the missing symbol to ignore (can be repeated)",
+					},
+					&cli.BoolFlag{
+						Name:  "all",
+						Usage: "Ignore all the missing symbols of the application",
+					},
+				),
+				Action: executeSymbolsIgnore,
+			},
+		},
+	}
+}
+
+func executeSymbolsUpload(c *cli.Context) error {
+	pterm.DefaultHeader.Println("GO AppCenter")
+
+	typ, err := appcenter.ParseSymbolType(c.String("type"))
+	if err != nil {
+		return err
+	}
+
+	_, err = newClient(c).Symbols.Upload(c, appcenter.SymbolUploadTask{
+		Type:     typ,
+		FilePath: c.String("file"),
+		Version:  c.String("versionName"),
+		Build:    c.String("versionCode"),
+	})
+
+	return err
+}
+
+func executeSymbolsList(c *cli.Context) error {
+	if err := setupOutput(c); err != nil {
+		return err
+	}
+
+	uploads, err := newClient(c).Symbols.List(c, c.String("status"))
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(c, uploads, symbolUploadsHeader, symbolUploadsRows(uploads))
+}
+
+func executeSymbolsMissing(c *cli.Context) error {
+	if err := setupOutput(c); err != nil {
+		return err
+	}
+
+	groups, err := newClient(c).Symbols.Missing(c)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(c, groups, missingSymbolsHeader, missingSymbolsRows(groups))
+}
+
+func executeSymbolsPrune(c *cli.Context) error {
+	policy := appcenter.StaleSymbolUploads{
+		Statuses:  c.StringSlice("status"),
+		OlderThan: c.Duration("olderThan"),
+	}
+
+	client := newClient(c)
+
+	uploads, err := client.Symbols.List(c, "")
+	if err != nil {
+		return err
+	}
+
+	stale := policy.Apply(uploads, time.Now())
+	if len(stale) == 0 {
+		appcenter.DefaultUI.Info("No stale symbol uploads")
+		return nil
+	}
+
+	if err := renderTable(symbolUploadsHeader, symbolUploadsRows(stale)); err != nil {
+		return err
+	}
+
+	if c.Bool("dryRun") {
+		return nil
+	}
+
+	if !c.Bool("yes") && !confirm(fmt.Sprintf("Delete %d symbol uploads?", len(stale))) {
+		appcenter.DefaultUI.Warning("Pruning aborted")
+		return nil
+	}
+
+	return client.Symbols.Prune(c, stale)
+}
+
+func executeSymbolsIgnore(c *cli.Context) error {
+	client := newClient(c)
+
+	ids := c.StringSlice("symbolId")
+	if c.Bool("all") {
+		groups, err := client.Symbols.Missing(c)
+		if err != nil {
+			return err
+		}
+
+		for _, g := range groups {
+			for _, s := range g.MissingSymbols {
+				ids = append(ids, s.ID)
+			}
+		}
+	}
+
+	if len(ids) == 0 {
+		appcenter.DefaultUI.Info("No symbols to ignore")
+		return nil
+	}
+
+	return client.Symbols.Ignore(c, ids)
+}
+
+var symbolUploadsHeader = []string{"ID", "Type", "Status", "File", "Size", "Started at"}
+
+func symbolUploadsRows(uploads []appcenter.SymbolUploadDetails) [][]string {
+	data := [][]string{}
+	for _, u := range uploads {
+		data = append(data, []string{
+			u.ID,
+			u.SymbolType,
+			u.Status,
+			u.FileName,
+			strconv.FormatInt(u.FileSize, 10),
+			u.Timestamp,
+		})
+	}
+
+	return data
+}
+
+var missingSymbolsHeader = []string{"Group", "Version", "Build", "Crashes", "Symbol", "Name", "Status"}
+
+func missingSymbolsRows(groups []appcenter.MissingSymbolGroup) [][]string {
+	data := [][]string{}
+	for _, g := range groups {
+		for _, s := range g.MissingSymbols {
+			data = append(data, []string{
+				g.ID,
+				g.AppVersion,
+				g.AppBuild,
+				strconv.Itoa(g.CrashCount),
+				s.ID,
+				s.Name,
+				s.Status,
+			})
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"goappcenter/appcenter"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+)
+
+func testersCommand() *cli.Command {
+	flags := append(appFlags(), releaseQueryFlags()...)
+
+	return &cli.Command{
+		Name:        "testers",
+		Description: "Report the invitation state of the members of a distribution group and their access to a release",
+		Flags: append(flags,
+			&cli.StringFlag{
+				Name:     "groupName",
+				Required: true,
+				Usage:    "Distribution group name",
+			},
+			outputFlag(),
+		),
+		Action: executeTesters,
+	}
+}
+
+func executeTesters(c *cli.Context) error {
+	if err := setupOutput(c); err != nil {
+		return err
+	}
+
+	report, err := newClient(c).Distribute.GroupAccessReport(c, releaseQuery(c), c.String("groupName"))
+	if err != nil {
+		return err
+	}
+
+	header, rows := testerRows(report)
+
+	return writeOutput(c, report, header, rows)
+}
+
+// testerRows returns the table of the testers, each row repeating the download counters of the
+// release for the group, left empty when they are unknown
+func testerRows(report *appcenter.GroupAccessReport) ([]string, [][]string) {
+	unique, total := "", ""
+	if report.Downloads != nil {
+		unique = strconv.FormatInt(report.Downloads.Unique, 10)
+		total = strconv.FormatInt(report.Downloads.Total, 10)
+	}
+
+	header := []string{"Release", "Group", "Email", "Display name", "Invitation", "Has access",
+		"Unique downloads", "Total downloads"}
+	rows := [][]string{}
+	for _, t := range report.Testers {
+		rows = append(rows, []string{
+			strconv.FormatInt(report.ReleaseID, 10),
+			report.GroupName,
+			t.Email,
+			t.DisplayName,
+			t.InvitationState,
+			strconv.FormatBool(t.HasAccess),
+			unique,
+			total,
+		})
+	}
+
+	return header, rows
+}
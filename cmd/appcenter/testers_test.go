@@ -0,0 +1,35 @@
+package main
+
+import (
+	"goappcenter/appcenter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+func TestTesterRows(t *testing.T) {
+	tester := appcenter.TesterAccess{
+		GroupMember:     appcenter.GroupMember{Email: "tester@test.com", DisplayName: "Tester"},
+		InvitationState: "accepted",
+		HasAccess:       true,
+	}
+	report := &appcenter.GroupAccessReport{
+		ReleaseID: 12,
+		GroupName: "Beta",
+		Downloads: &appcenter.ReleaseDownloads{Unique: 3, Total: 5},
+		Testers:   []appcenter.TesterAccess{tester},
+	}
+
+	c := testContext(t, []cli.Flag{outputFlag()}, "--output", "csv")
+	stdout, _ := captureOutput(t, func() {
+		header, rows := testerRows(report)
+		assert.NoError(t, writeOutput(c, report, header, rows))
+	})
+	assert.Equal(t, "Release,Group,Email,Display name,Invitation,Has access,Unique downloads,Total downloads\n"+
+		"12,Beta,tester@test.com,Tester,accepted,true,3,5\n", stdout)
+
+	report.Downloads = nil
+	_, rows := testerRows(report)
+	assert.Equal(t, []string{"", ""}, rows[0][6:], "unknown counters are left empty")
+}
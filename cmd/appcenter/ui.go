@@ -0,0 +1,84 @@
+package main
+
+import (
+	"goappcenter/appcenter"
+	"io/ioutil"
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+)
+
+// plainUI is the UI of the clients when the progress is reported as plain lines, kept to move
+// its output to the standard error with the machine readable formats
+var plainUI *appcenter.PlainUI
+
+// uiFlags select how the progress of the commands is reported
+func uiFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "quiet",
+			Aliases: []string{"q"},
+			EnvVars: []string{"AppCenterQuiet"},
+			Usage:   "Only print the errors and the result of the command",
+		},
+		&cli.BoolFlag{
+			Name:    "no-progress",
+			EnvVars: []string{"AppCenterNoProgress"},
+			Usage:   "Report the progress as plain timestamped lines instead of spinners",
+		},
+	}
+}
+
+// setupUI selects the UI of the clients: nothing with --quiet, which also only logs the errors, plain lines with --no-progress or
+// when the standard output is not a terminal, the pterm spinners otherwise. The colors are
+// disabled when the NO_COLOR environment variable is set or the output is not a terminal.
+func setupUI(c *cli.Context) error {
+	if noColor() || !isTerminal(os.Stdout) {
+		pterm.DisableColor()
+	}
+
+	switch {
+	case c.Bool("quiet"):
+		pterm.SetDefaultOutput(ioutil.Discard)
+		appcenter.DefaultUI = appcenter.QuietUI{Out: os.Stdout, Err: os.Stderr}
+		if !c.IsSet("log-level") {
+			log.Logger = log.Logger.Level(zerolog.ErrorLevel)
+		}
+
+	case c.Bool("no-progress") || !isTerminal(os.Stdout):
+		plainUI = appcenter.NewPlainUI(os.Stdout)
+		appcenter.DefaultUI = plainUI
+	}
+
+	return nil
+}
+
+// progressToStderr moves the progress reporting to the standard error, so the standard output
+// only contains the result of the command. The quiet UI stops printing the results, replaced by the
+// machine readable output.
+func progressToStderr(c *cli.Context) {
+	if c.Bool("quiet") {
+		appcenter.DefaultUI = appcenter.QuietUI{Err: os.Stderr}
+		return
+	}
+
+	pterm.SetDefaultOutput(os.Stderr)
+	if plainUI != nil {
+		plainUI.Out = os.Stderr
+	}
+}
+
+// noColor reports whether the colors are disabled, following https://no-color.org
+func noColor() bool {
+	_, ok := os.LookupEnv("NO_COLOR")
+	return ok
+}
+
+// isTerminal reports whether the file is a terminal
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"goappcenter/appcenter"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// uploadTasks returns the upload tasks of the binaries matching the patterns and provided through
+// --manifest
+func uploadTasks(c *cli.Context, patterns []string) ([]appcenter.UploadTask, error) {
+	tasks := []appcenter.UploadTask{}
+
+	if m := c.String("manifest"); m != "" {
+		t, err := appcenter.LoadBatchManifest(m, request)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t...)
+	}
+
+	if len(patterns) > 0 {
+		files, err := appcenter.ExpandArtifacts(patterns)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range files {
+			t := request
+			t.FilePath = f
+			tasks = append(tasks, t)
+		}
+	}
+
+	if len(tasks) == 0 {
+		return nil, cli.Exit("At least one binary must be provided with '--file' or '--manifest'", exitValidation)
+	}
+
+	return tasks, nil
+}
+
+// renderBatchResults prints the result of each upload, and fails if any of them failed with the
+// exit code of the first failure
+func renderBatchResults(results []appcenter.BatchResult) error {
+	rows := [][]string{}
+	failed, code := 0, exitOK
+
+	for _, r := range results {
+		status, release := "OK", strconv.FormatInt(r.ReleaseID, 10)
+		if r.Err != nil {
+			failed++
+			status, release = r.Err.Error(), ""
+
+			// the exit code is the one of the first failed upload
+			if code == exitOK {
+				code = exitCode(r.Err)
+			}
+		}
+
+		rows = append(rows, []string{
+			filepath.Base(r.Task.FilePath),
+			fmt.Sprintf("%v/%v", r.Task.OwnerName, r.Task.AppName),
+			release,
+			r.Duration.Round(time.Second).String(),
+			status,
+		})
+	}
+
+	if err := renderTable([]string{"Artifact", "App", "Release", "Duration", "Status"}, rows); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return cli.Exit(fmt.Sprintf("%d of %d uploads failed", failed, len(results)), code)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"goappcenter/appcenter"
+	"goappcenter/config"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	outputYAML = "yaml"
+	outputEnv  = "env"
+)
+
+// uploadOutputFlags select the format and destination of the upload result
+func uploadOutputFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Value:   outputTable,
+			Usage:   "Output format of the upload result (table, json, yaml or env)",
+		},
+		&cli.PathFlag{
+			Name:  "output-file",
+			Usage: "Write the upload result to this file instead of the standard output",
+		},
+	}
+}
+
+// setupUploadOutput validates the output format of the upload result and, for machine readable
+// formats, moves the progress reporting to the standard error
+func setupUploadOutput(c *cli.Context, tasks int) error {
+	switch c.String("output") {
+	case outputTable:
+		if c.String("output-file") != "" {
+			return fmt.Errorf("'--output-file' requires the json, yaml or env output")
+		}
+		return nil
+
+	case outputEnv:
+		if tasks > 1 {
+			return fmt.Errorf("The env output only supports the upload of a single binary")
+		}
+
+	case outputJSON, outputYAML:
+	default:
+		return fmt.Errorf("Unsupported output format '%v'", c.String("output"))
+	}
+
+	progressToStderr(c)
+	return nil
+}
+
+// writeUploadOutput writes the summaries of the uploads in the selected format
+func writeUploadOutput(c *cli.Context, summaries []*appcenter.UploadSummary) error {
+	if c.String("output") == outputTable {
+		return nil
+	}
+
+	return writeUploadResult(c, summaries)
+}
+
+// writeUploadResult writes the list in the format selected by the uploadOutputFlags, to the
+// output file or the standard output. A list of a single element is written as an object.
+func writeUploadResult(c *cli.Context, list interface{}) error {
+	v := list
+	if l := reflect.ValueOf(list); l.Kind() == reflect.Slice && l.Len() == 1 {
+		v = l.Index(0).Interface()
+	}
+
+	var buf bytes.Buffer
+	if err := encodeUploadOutput(&buf, c.String("output"), v); err != nil {
+		return err
+	}
+
+	if path := c.String("output-file"); path != "" {
+		return ioutil.WriteFile(path, buf.Bytes(), 0644)
+	}
+
+	_, err := buf.WriteTo(os.Stdout)
+	return err
+}
+
+// resultOnStdout reports whether the upload result is written to the standard output
+func resultOnStdout(c *cli.Context) bool {
+	return c.String("output") != outputTable && c.String("output-file") == ""
+}
+
+func encodeUploadOutput(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+
+	case outputYAML:
+		return config.EncodeYAML(w, v)
+
+	case outputEnv:
+		for _, o := range v.(*appcenter.UploadSummary).Outputs() {
+			if _, err := fmt.Fprintf(w, "APPCENTER_%v=%v\n", strings.ToUpper(o.Name), shellQuote(o.Value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("Unsupported output format '%v'", format)
+}
+
+// shellQuote quotes the value so the env output can be sourced by a shell or read as a dotenv file
+func shellQuote(v string) string {
+	return "'" + strings.Replace(v, "'", `'\''`, -1) + "'"
+}
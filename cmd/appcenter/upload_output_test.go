@@ -0,0 +1,35 @@
+package main
+
+import (
+	"goappcenter/appcenter"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteUploadResult(t *testing.T) {
+	dir, err := ioutil.TempDir("", "appcenter")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "result.json")
+
+	c := testContext(t, uploadOutputFlags(), "--output", "json", "--output-file", path)
+
+	plan := &appcenter.UploadPlan{ContentType: "application/vnd.android.package-archive", Steps: []string{"Upload"}}
+	assert.NoError(t, writeUploadResult(c, []*appcenter.UploadPlan{plan}))
+	assert.JSONEq(t, `{"app": null, "content_type": "application/vnd.android.package-archive", "steps": ["Upload"]}`,
+		readFile(t, path), "a single result is written as an object")
+
+	summary := &appcenter.UploadSummary{ReleaseID: 12, Timings: []appcenter.StageTiming{}}
+	assert.NoError(t, writeUploadResult(c, []*appcenter.UploadSummary{summary, summary}))
+	assert.JSONEq(t, `[{"release_id": 12, "timings": []}, {"release_id": 12, "timings": []}]`, readFile(t, path))
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"goappcenter/appcenter"
+	"strconv"
+
+	"github.com/pterm/pterm"
+	"github.com/urfave/cli/v2"
+)
+
+// dryRunFlag checks the upload without uploading nor distributing anything
+func dryRunFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:    "dry-run",
+		Aliases: []string{"dryRun"},
+		Usage:   "Check the binaries, the application, the role of the API token user and the destinations, and print the planned steps without uploading",
+	}
+}
+
+// executeUploadPlan checks the upload tasks and prints their planned steps. It stops at the first
+// failing task.
+func executeUploadPlan(c *cli.Context, tasks []appcenter.UploadTask) error {
+	if c.String("output") == outputEnv {
+		return cli.Exit("The env output is not supported with '--dry-run'", exitValidation)
+	}
+
+	client := appcenter.NewClient(APIKey)
+
+	plans := []*appcenter.UploadPlan{}
+	for _, t := range tasks {
+		if t.OwnerName == "" || t.AppName == "" {
+			return cli.Exit("'--ownerName' and '--appName' must be provided", exitValidation)
+		}
+
+		plan, err := client.WithApp(t.OwnerName, t.AppName).Upload.Plan(c, t)
+		if err != nil {
+			return err
+		}
+		plans = append(plans, plan)
+	}
+
+	if c.String("output") != outputTable {
+		return writeUploadResult(c, plans)
+	}
+
+	for i, plan := range plans {
+		rows := [][]string{}
+		for n, s := range plan.Steps {
+			rows = append(rows, []string{strconv.Itoa(n + 1), s})
+		}
+
+		pterm.DefaultSection.Println(fmt.Sprintf("Planned steps for %v/%v", tasks[i].OwnerName, tasks[i].AppName))
+		if err := renderTable([]string{"Step", "Action"}, rows); err != nil {
+			return err
+		}
+	}
+
+	appcenter.DefaultUI.Info("Dry run: nothing was uploaded")
+	return nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"goappcenter/appcenter"
+	"goappcenter/selfupdate"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Build information, injected at build time:
+//
+//	go build -ldflags "-X main.version=0.3.0 -X main.commit=$(git rev-parse HEAD) -X main.date=$(date -u +%FT%TZ)"
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
+// updatePublicKey is the base64 ed25519 public key verifying the releases installed by
+// self-update, injected at build time with -X main.updatePublicKey=<key>
+var updatePublicKey = ""
+
+// buildInfo is the output of version --json
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+func versionCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "version",
+		Description: "Print the version, commit and build date of the command line",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Print the build information as JSON",
+			},
+		},
+		Action: executeVersion,
+	}
+}
+
+func executeVersion(c *cli.Context) error {
+	info := buildInfo{
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		Platform:  fmt.Sprintf("%v/%v", runtime.GOOS, runtime.GOARCH),
+	}
+
+	if c.Bool("json") {
+		enc := json.NewEncoder(c.App.Writer)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	_, err := fmt.Fprintf(c.App.Writer, "go-appcenter %v (commit %v, built %v, %v %v)\n",
+		info.Version, info.Commit, info.Date, info.GoVersion, info.Platform)
+	return err
+}
+
+func selfUpdateCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "self-update",
+		Description: "Replace the binary with the latest release, once its checksum and signature are verified",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "url",
+				EnvVars: []string{"AppCenterUpdateURL"},
+				Usage:   "Base URL of the releases, serving latest.json and <version>/checksums.txt",
+			},
+			&cli.StringFlag{
+				Name:    "publicKey",
+				EnvVars: []string{"AppCenterUpdatePublicKey"},
+				Value:   updatePublicKey,
+				Usage:   "Base64 ed25519 public key verifying the signature of the checksums (checksums.txt.sig), the one of the build by default",
+			},
+			&cli.BoolFlag{
+				Name:  "insecure",
+				Usage: "Install the release without public key, only verifying its checksum",
+			},
+			&cli.BoolFlag{
+				Name:  "check",
+				Usage: "Only check whether a newer release is available",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Install the latest release even if it is not newer than the running one",
+			},
+		},
+		Action: executeSelfUpdate,
+	}
+}
+
+func executeSelfUpdate(c *cli.Context) error {
+	if c.String("url") == "" {
+		return cli.Exit("Required flag \"url\" not set", exitValidation)
+	}
+
+	u := selfupdate.New(c.String("url"))
+	u.Insecure = c.Bool("insecure")
+	if key := c.String("publicKey"); key != "" {
+		pub, err := selfupdate.ParsePublicKey(key)
+		if err != nil {
+			return cli.Exit(err.Error(), exitValidation)
+		}
+		u.PublicKey = pub
+	} else if !u.Insecure && !c.Bool("check") {
+		return cli.Exit("No public key to verify the release: set --publicKey, or --insecure to only verify its checksum", exitValidation)
+	}
+
+	sp, err := appcenter.DefaultUI.Start("Checking the latest release")
+	if err != nil {
+		return err
+	}
+
+	latest, err := u.Latest(c.Context)
+	if err != nil {
+		sp.Fail(fmt.Sprintf("Failed to check the latest release: %v", err))
+		return err
+	}
+
+	if !selfupdate.Newer(latest.Version, version) && !c.Bool("force") {
+		sp.Success(fmt.Sprintf("go-appcenter %v is up to date", version))
+		return nil
+	}
+
+	if c.Bool("check") {
+		sp.Warning(fmt.Sprintf("go-appcenter %v is available (running %v)", latest.Version, version))
+		return nil
+	}
+
+	sp.UpdateText(fmt.Sprintf("Downloading go-appcenter %v", latest.Version))
+	bin, err := u.Download(c.Context, latest)
+	if err != nil {
+		sp.Fail(fmt.Sprintf("Failed to download the release: %v", err))
+		return err
+	}
+
+	path, err := executable()
+	if err != nil {
+		sp.Fail()
+		return err
+	}
+
+	if err := selfupdate.Replace(path, bin); err != nil {
+		sp.Fail(fmt.Sprintf("Failed to replace `%v`: %v", path, err))
+		return err
+	}
+
+	sp.Success(fmt.Sprintf("Updated go-appcenter from %v to %v", version, latest.Version))
+	return nil
+}
+
+// executable returns the path of the running binary, the target of the symbolic links
+func executable() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.EvalSymlinks(path)
+}
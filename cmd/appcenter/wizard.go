@@ -0,0 +1,276 @@
+package main
+
+import (
+	"fmt"
+	"goappcenter/appcenter"
+	"goappcenter/config"
+	"goappcenter/inspect"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// binaryExtensions are the extensions of the binaries suggested by the wizard
+var binaryExtensions = []string{".ipa", ".apk", ".aab", ".msi", ".appx", ".msix", ".appxbundle", ".msixbundle"}
+
+// skippedDirs are not searched for binaries
+var skippedDirs = map[string]bool{"node_modules": true, "Pods": true, "vendor": true}
+
+// maxBinaryDepth is the depth of the directories searched for binaries
+const maxBinaryDepth = 4
+
+// interactiveFlag asks the upload settings instead of reading them from the flags
+func interactiveFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:    "interactive",
+		Aliases: []string{"i"},
+		Usage:   "Pick the organization, application, binary, version and groups interactively",
+	}
+}
+
+// runWizard asks the owner, application, binary, version and distribution groups of the upload,
+// the flags and configuration file providing the default answers. It then offers to save the
+// answers as a target of the configuration file. It returns the binary to upload.
+func runWizard(c *cli.Context, files []string) ([]string, error) {
+	if c.String("manifest") != "" || len(files) > 1 {
+		return nil, cli.Exit("'--interactive' uploads a single binary", exitValidation)
+	}
+
+	p, err := newPrompter()
+	if err != nil {
+		return nil, cli.Exit(err.Error(), exitValidation)
+	}
+
+	client := appcenter.NewClient(APIKey)
+
+	owners, err := wizardOwners(c, client)
+	if err != nil {
+		return nil, err
+	}
+	if request.OwnerName, err = p.choose("Organization", owners, request.OwnerName); err != nil {
+		return nil, err
+	}
+
+	apps, err := wizardApps(c, client, request.OwnerName)
+	if err != nil {
+		return nil, err
+	}
+	if request.AppName, err = p.choose("Application", apps, request.AppName); err != nil {
+		return nil, err
+	}
+
+	def := ""
+	if len(files) == 1 {
+		def = files[0]
+	}
+	file, err := p.choose("Binary", binaryOptions("."), def)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wizardVersion(p, file); err != nil {
+		return nil, err
+	}
+
+	app := client.WithApp(request.OwnerName, request.AppName)
+	groups, err := wizardGroups(c, app)
+	if err != nil {
+		return nil, err
+	}
+	if request.Distribute.GroupNames, err = p.chooseMany("Distribution groups", groups, request.Distribute.GroupNames); err != nil {
+		return nil, err
+	}
+
+	if err := wizardSave(c, p, file); err != nil {
+		return nil, err
+	}
+
+	return []string{file}, nil
+}
+
+func wizardOwners(c *cli.Context, client *appcenter.Client) ([]option, error) {
+	sp, err := client.UI.Start("Listing the organizations")
+	if err != nil {
+		return nil, err
+	}
+
+	owners, err := listOwners(c, client)
+	if err != nil {
+		sp.Warning(fmt.Sprintf("Failed to list the organizations: %v", err))
+		return nil, nil
+	}
+	sp.Success(fmt.Sprintf("%d organizations found", len(owners)))
+
+	options := []option{}
+	for _, o := range owners {
+		options = append(options, option{value: o, label: o})
+	}
+
+	return options, nil
+}
+
+func wizardApps(c *cli.Context, client *appcenter.Client, owner string) ([]option, error) {
+	sp, err := client.UI.Start(fmt.Sprintf("Listing the applications of %v", owner))
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := client.Apps.List(c)
+	if err != nil {
+		sp.Warning(fmt.Sprintf("Failed to list the applications: %v", err))
+		return nil, nil
+	}
+
+	options := []option{}
+	for _, a := range apps {
+		if a.Owner != nil && a.Owner.Name == owner {
+			options = append(options, option{value: a.Name, label: fmt.Sprintf("%v (%v, %v)", a.Name, a.DisplayName, a.OS)})
+		}
+	}
+	sp.Success(fmt.Sprintf("%d applications found", len(options)))
+
+	return options, nil
+}
+
+func wizardGroups(c *cli.Context, client *appcenter.Client) ([]option, error) {
+	sp, err := client.UI.Start("Listing the distribution groups")
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := client.Distribute.Groups(c)
+	if err != nil {
+		sp.Warning(fmt.Sprintf("Failed to list the distribution groups: %v", err))
+		return nil, nil
+	}
+	sp.Success(fmt.Sprintf("%d distribution groups found", len(groups)))
+
+	options := []option{}
+	for _, g := range groups {
+		label := g.Name
+		if g.IsPublic {
+			label += " (public)"
+		}
+		options = append(options, option{value: g.Name, label: label})
+	}
+
+	return options, nil
+}
+
+// wizardVersion asks the build version and number, suggesting the ones of the binary
+func wizardVersion(p *prompter, file string) error {
+	version, number := request.Option.BuildVersion, request.Option.BuildNumber
+
+	info, err := inspect.Inspect(file)
+	if err == nil {
+		appcenter.DefaultUI.Info(fmt.Sprintf("%v %v binary %v, version %v (%v)",
+			info.Platform, strings.ToUpper(info.Format), info.Identifier, info.BuildVersion, info.BuildNumber))
+
+		if version == "" {
+			version = info.BuildVersion
+		}
+		if number == "" {
+			number = info.BuildNumber
+		}
+	} else if err != inspect.ErrUnsupportedFormat {
+		appcenter.DefaultUI.Warning(fmt.Sprintf("Failed to inspect the binary: %v", err))
+	}
+
+	if request.Option.BuildVersion, err = p.input("Build version", version); err != nil {
+		return err
+	}
+
+	request.Option.BuildNumber, err = p.input("Build number", number)
+	return err
+}
+
+// wizardSave offers to save the answers as a target of the configuration file, created in the
+// working directory if needed. An existing file is only rewritten, without its comments, once
+// confirmed. The version is not saved as it changes with every build.
+func wizardSave(c *cli.Context, p *prompter, file string) error {
+	save, err := p.confirm("Save these settings as a target of the configuration file?", false)
+	if err != nil || !save {
+		return err
+	}
+
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		cfg = &config.Config{Path: config.Files[0]}
+	} else {
+		rewrite, err := p.confirm(fmt.Sprintf("`%v` is rewritten as a whole, its comments and layout are lost. Continue?", cfg.Path), false)
+		if err != nil || !rewrite {
+			return err
+		}
+	}
+
+	name, err := p.required("Target name", request.AppName)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cfg.Targets[name]; ok {
+		replace, err := p.confirm(fmt.Sprintf("Target '%v' already exists, replace it?", name), false)
+		if err != nil || !replace {
+			return err
+		}
+	}
+
+	// the file is relative to the configuration file
+	if abs, err := filepath.Abs(file); err == nil {
+		if dir, err := filepath.Abs(filepath.Dir(cfg.Path)); err == nil {
+			if rel, err := filepath.Rel(dir, abs); err == nil {
+				file = filepath.ToSlash(rel)
+			}
+		}
+	}
+
+	cfg.SetTarget(name, config.Target{
+		OwnerName:  request.OwnerName,
+		AppName:    request.AppName,
+		File:       file,
+		GroupNames: request.Distribute.GroupNames,
+	})
+
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	appcenter.DefaultUI.Success(fmt.Sprintf("Target '%v' saved in `%v`, use --target %v for the next uploads", name, cfg.Path, name))
+	return nil
+}
+
+// binaryOptions returns the binaries found in the directory and its subdirectories
+func binaryOptions(root string) []option {
+	options := []option{}
+
+	//nolint:errcheck
+	filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if fi.IsDir() {
+			name := fi.Name()
+			if path != root && (strings.HasPrefix(name, ".") || skippedDirs[name] ||
+				strings.Count(filepath.ToSlash(path), "/") >= maxBinaryDepth) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		for _, ext := range binaryExtensions {
+			if strings.EqualFold(filepath.Ext(path), ext) {
+				options = append(options, option{value: path, label: path})
+			}
+		}
+
+		return nil
+	})
+
+	return options
+}
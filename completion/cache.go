@@ -0,0 +1,122 @@
+// Package completion provides the shell completion scripts of the command line, and the cache of
+// the values completed dynamically from AppCenter (owners, applications, distribution groups)
+package completion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultTTL is the duration the completion values are cached for
+const DefaultTTL = time.Hour
+
+// Cache stores the completion values on disk, so the completion stays fast and does not call
+// AppCenter on every key stroke
+type Cache struct {
+	// Path of the cache file
+	Path string
+
+	// TTL is the duration the values are kept for
+	TTL time.Duration
+
+	Now func() time.Time
+}
+
+type entry struct {
+	Time   time.Time `json:"time"`
+	Values []string  `json:"values"`
+}
+
+// DefaultCache returns the cache stored in the user cache directory
+func DefaultCache() Cache {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return Cache{
+		Path: filepath.Join(dir, "go-appcenter", "completion.json"),
+		TTL:  DefaultTTL,
+		Now:  time.Now,
+	}
+}
+
+// Key returns the cache key of the values, specific to the API key as the tokens do not see the
+// same owners and applications. The API key itself is not stored.
+func Key(apiKey string, parts ...string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return strings.Join(append([]string{hex.EncodeToString(sum[:8])}, parts...), "/")
+}
+
+// Values returns the cached values of the key, or fetches and caches them when they are missing
+// or expired. Failing to read or write the cache only disables it.
+func (c Cache) Values(key string, fetch func() ([]string, error)) ([]string, error) {
+	entries := c.read()
+	now := c.Now()
+
+	if e, ok := entries[key]; ok && now.Sub(e.Time) < c.TTL {
+		return e.Values, nil
+	}
+
+	values, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	for k, e := range entries {
+		if now.Sub(e.Time) >= c.TTL {
+			delete(entries, k)
+		}
+	}
+	entries[key] = entry{Time: now, Values: values}
+	//nolint:errcheck
+	c.write(entries)
+
+	return values, nil
+}
+
+func (c Cache) read() map[string]entry {
+	entries := map[string]entry{}
+
+	data, err := ioutil.ReadFile(c.Path)
+	if err == nil && json.Unmarshal(data, &entries) == nil {
+		return entries
+	}
+
+	return map[string]entry{}
+}
+
+// write replaces the cache file, through a temporary file so concurrent completions never read
+// a partial file
+func (c Cache) write(entries map[string]entry) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0700); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(c.Path), "completion")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), c.Path)
+}
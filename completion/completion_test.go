@@ -0,0 +1,86 @@
+package completion
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "completion")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := Cache{
+		Path: filepath.Join(dir, "cache", "completion.json"),
+		TTL:  time.Hour,
+		Now:  func() time.Time { return now },
+	}
+
+	calls := 0
+	fetch := func(values ...string) func() ([]string, error) {
+		return func() ([]string, error) {
+			calls++
+			return values, nil
+		}
+	}
+
+	key := Key("api-key", "apps", "owner")
+	assert.NotContains(t, key, "api-key")
+	assert.NotEqual(t, key, Key("other-key", "apps", "owner"))
+
+	values, err := c.Values(key, fetch("a", "b"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, values)
+
+	// cached
+	now = now.Add(30 * time.Minute)
+	values, err = c.Values(key, fetch("c"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, values)
+	assert.Equal(t, 1, calls)
+
+	// expired
+	now = now.Add(time.Hour)
+	values, err = c.Values(key, fetch("c"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c"}, values)
+	assert.Equal(t, 2, calls)
+
+	// the errors are not cached
+	_, err = c.Values(Key("api-key", "orgs"), func() ([]string, error) { return nil, errors.New("failed") })
+	assert.Error(t, err)
+	values, err = c.Values(Key("api-key", "orgs"), fetch("owner"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"owner"}, values)
+
+	// a corrupted cache is ignored
+	assert.NoError(t, ioutil.WriteFile(c.Path, []byte("{"), 0600))
+	values, err = c.Values(key, fetch("d"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"d"}, values)
+}
+
+func TestScript(t *testing.T) {
+	for _, shell := range Shells {
+		script, err := Script(shell, "go-appcenter")
+		assert.NoError(t, err)
+		assert.Contains(t, script, "go-appcenter")
+		assert.Contains(t, script, "--generate-bash-completion")
+		assert.False(t, strings.Contains(script, "{{"), shell)
+	}
+
+	script, err := Script("bash", "go-appcenter")
+	assert.NoError(t, err)
+	assert.Contains(t, script, "complete -o bashdefault -o default -F _go_appcenter_complete go-appcenter")
+
+	_, err = Script("tcsh", "go-appcenter")
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package completion
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shells are the shells a completion script is provided for
+var Shells = []string{"bash", "zsh", "fish", "powershell"}
+
+// The scripts call the program with the words typed so far and the --generate-bash-completion
+// flag, the program printing the candidates one per line
+var scripts = map[string]string{
+	"bash": `_{{func}}_complete() {
+  local cur opts
+  COMPREPLY=()
+  cur="${COMP_WORDS[COMP_CWORD]}"
+  if [[ "$cur" == "-"* ]]; then
+    opts=$( "${COMP_WORDS[@]:0:$COMP_CWORD}" "$cur" --generate-bash-completion 2>/dev/null )
+  else
+    opts=$( "${COMP_WORDS[@]:0:$COMP_CWORD}" --generate-bash-completion 2>/dev/null )
+  fi
+  COMPREPLY=( $(compgen -W "${opts}" -- "${cur}") )
+  return 0
+}
+
+complete -o bashdefault -o default -F _{{func}}_complete {{prog}}
+`,
+
+	"zsh": `#compdef {{prog}}
+
+_{{func}}_complete() {
+  local -a opts
+  local cur="${words[CURRENT]}"
+  if [[ "$cur" == -* ]]; then
+    opts=("${(@f)$(${words[@]:0:CURRENT-1} "$cur" --generate-bash-completion 2>/dev/null)}")
+  else
+    opts=("${(@f)$(${words[@]:0:CURRENT-1} --generate-bash-completion 2>/dev/null)}")
+  fi
+  compadd -a opts
+}
+
+compdef _{{func}}_complete {{prog}}
+`,
+
+	"fish": `function __{{func}}_complete
+    set -l args (commandline -opc)
+    set -l cur (commandline -ct)
+    if string match -q -- '-*' $cur
+        $args $cur --generate-bash-completion 2>/dev/null
+    else
+        $args --generate-bash-completion 2>/dev/null
+    end
+end
+
+complete -c {{prog}} -f -a '(__{{func}}_complete)'
+`,
+
+	"powershell": `Register-ArgumentCompleter -Native -CommandName '{{prog}}' -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $words = @($commandAst.CommandElements | ForEach-Object { $_.ToString() })
+    if ($wordToComplete -ne '') {
+        $words = $words[0..($words.Count - 2)]
+    }
+    $arguments = @($words | Select-Object -Skip 1)
+    if ($wordToComplete.StartsWith('-')) {
+        $arguments += $wordToComplete
+    }
+
+    & $words[0] @arguments --generate-bash-completion 2>$null |
+        Where-Object { $_ -like "$wordToComplete*" } |
+        ForEach-Object { [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_) }
+}
+`,
+}
+
+// Script returns the completion script of the shell for the program
+func Script(shell string, prog string) (string, error) {
+	script, ok := scripts[shell]
+	if !ok {
+		return "", fmt.Errorf("Unsupported shell '%v' (supported: %v)", shell, strings.Join(Shells, ", "))
+	}
+
+	// shell function names only allow a subset of the program name characters
+	fn := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, prog)
+
+	return strings.NewReplacer("{{prog}}", prog, "{{func}}", fn).Replace(script), nil
+}
@@ -0,0 +1,256 @@
+// Package config reads the project configuration file defining the targets an application is
+// uploaded to: owner, application, binaries, destinations, release notes and symbols
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Files are the names of the configuration file looked up in the working directory, by order
+// of preference
+var Files = []string{".appcenter.yml", ".appcenter.yaml", ".appcenter.toml", ".appcenter.json"}
+
+// Config is the project configuration: the default settings and the named targets
+type Config struct {
+	// Defaults are the settings of all the targets, and of the uploads without target
+	Defaults Target `json:"defaults"`
+
+	// Targets are the named settings selected with --target (ex: ios-beta)
+	Targets map[string]Target `json:"targets"`
+
+	// Path of the configuration file
+	Path string `json:"-"`
+}
+
+// Target are the settings of an upload
+type Target struct {
+	OwnerName        string   `json:"ownerName,omitempty"`
+	AppName          string   `json:"appName,omitempty"`
+	File             string   `json:"file,omitempty"`
+	BuildVersion     string   `json:"buildVersion,omitempty"`
+	BuildNumber      string   `json:"buildNumber,omitempty"`
+	GroupNames       []string `json:"groupName,omitempty"`
+	Testers          []string `json:"tester,omitempty"`
+	StoreNames       []string `json:"storeName,omitempty"`
+	Mandatory        *bool    `json:"mandatory,omitempty"`
+	Notify           *bool    `json:"notify,omitempty"`
+	WaitForStore     *bool    `json:"waitForStore,omitempty"`
+	RolloutFraction  *float64 `json:"rolloutFraction,omitempty"`
+	ReleaseNotes     string   `json:"releaseNotes,omitempty"`
+	ReleaseNotesFile string   `json:"releaseNotesFile,omitempty"`
+	Symbols          string   `json:"symbols,omitempty"`
+}
+
+// Find returns the path of the configuration file of the directory, or an empty path if there
+// is none
+func Find(dir string) string {
+	for _, name := range Files {
+		p := filepath.Join(dir, name)
+		if fi, err := os.Stat(p); err == nil && !fi.IsDir() {
+			return p
+		}
+	}
+
+	return ""
+}
+
+// Load reads the configuration file, in the YAML, TOML or JSON format according to its
+// extension. Unknown settings are rejected.
+func Load(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		var table map[string]interface{}
+		err = toml.Unmarshal(data, &table)
+		raw = table
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	default:
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid configuration file `%v`: %v", path, err)
+	}
+
+	// the formats are decoded the same way, through their JSON representation
+	normalized, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid configuration file `%v`: %v", path, err)
+	}
+
+	c := Config{Path: path}
+	dec := json.NewDecoder(bytes.NewReader(normalized))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return nil, fmt.Errorf("Invalid configuration file `%v`: %v", path, err)
+	}
+
+	return &c, nil
+}
+
+// Names returns the names of the targets, sorted
+func (c *Config) Names() []string {
+	names := []string{}
+	for name := range c.Targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Target returns the settings of the named target merged with the defaults, or the defaults when
+// the name is empty
+func (c *Config) Target(name string) (Target, error) {
+	if name == "" {
+		return c.Defaults, nil
+	}
+
+	t, ok := c.Targets[name]
+	if !ok {
+		return Target{}, fmt.Errorf("Unknown target '%v' in `%v` (available: %v)",
+			name, c.Path, strings.Join(c.Names(), ", "))
+	}
+
+	return t.merge(c.Defaults), nil
+}
+
+// merge completes the settings of the target with the defaults
+func (t Target) merge(defaults Target) Target {
+	str := func(dst *string, v string) {
+		if *dst == "" {
+			*dst = v
+		}
+	}
+	str(&t.OwnerName, defaults.OwnerName)
+	str(&t.AppName, defaults.AppName)
+	str(&t.File, defaults.File)
+	str(&t.BuildVersion, defaults.BuildVersion)
+	str(&t.BuildNumber, defaults.BuildNumber)
+	str(&t.Symbols, defaults.Symbols)
+
+	// the release notes are either inline or read from a file
+	if t.ReleaseNotes == "" && t.ReleaseNotesFile == "" {
+		t.ReleaseNotes, t.ReleaseNotesFile = defaults.ReleaseNotes, defaults.ReleaseNotesFile
+	}
+
+	// the destinations are replaced as a whole
+	if len(t.GroupNames) == 0 && len(t.Testers) == 0 && len(t.StoreNames) == 0 {
+		t.GroupNames, t.Testers, t.StoreNames = defaults.GroupNames, defaults.Testers, defaults.StoreNames
+	}
+
+	if t.Mandatory == nil {
+		t.Mandatory = defaults.Mandatory
+	}
+	if t.Notify == nil {
+		t.Notify = defaults.Notify
+	}
+	if t.WaitForStore == nil {
+		t.WaitForStore = defaults.WaitForStore
+	}
+	if t.RolloutFraction == nil {
+		t.RolloutFraction = defaults.RolloutFraction
+	}
+
+	return t
+}
+
+// Notes returns the release notes of the target, read from the release notes file if needed. The
+// file is relative to the configuration file.
+func (c *Config) Notes(t Target) (string, error) {
+	if t.ReleaseNotesFile == "" {
+		return t.ReleaseNotes, nil
+	}
+
+	data, err := ioutil.ReadFile(c.resolve(t.ReleaseNotesFile))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// resolve returns the path relative to the configuration file
+func (c *Config) resolve(path string) string {
+	if path == "" || filepath.IsAbs(path) || strings.Contains(path, "://") {
+		return path
+	}
+
+	return filepath.Join(filepath.Dir(c.Path), path)
+}
+
+// FilePattern returns the file glob of the target, relative to the configuration file
+func (c *Config) FilePattern(t Target) string {
+	return c.resolve(t.File)
+}
+
+// SymbolsDir returns the symbols directory of the target, relative to the configuration file
+func (c *Config) SymbolsDir(t Target) string {
+	return c.resolve(t.Symbols)
+}
+
+// Validate checks the settings of the targets, and returns the problems found
+func (c *Config) Validate() []error {
+	problems := []error{}
+
+	names := append([]string{""}, c.Names()...)
+	for _, name := range names {
+		t, _ := c.Target(name)
+
+		label := "defaults"
+		if name != "" {
+			label = fmt.Sprintf("target '%v'", name)
+		}
+
+		for _, err := range c.validate(t, name != "") {
+			problems = append(problems, fmt.Errorf("%v: %v", label, err))
+		}
+	}
+
+	return problems
+}
+
+func (c *Config) validate(t Target, target bool) []error {
+	problems := []error{}
+
+	if target && (t.OwnerName == "" || t.AppName == "") {
+		problems = append(problems, fmt.Errorf("the owner name and app name are required"))
+	}
+
+	if t.ReleaseNotes != "" && t.ReleaseNotesFile != "" {
+		problems = append(problems, fmt.Errorf("releaseNotes and releaseNotesFile are exclusive"))
+	}
+
+	if t.ReleaseNotesFile != "" {
+		if _, err := os.Stat(c.resolve(t.ReleaseNotesFile)); err != nil {
+			problems = append(problems, fmt.Errorf("release notes file `%v` not found", t.ReleaseNotesFile))
+		}
+	}
+
+	if t.File != "" && !strings.Contains(t.File, "://") {
+		if _, err := filepath.Glob(c.FilePattern(t)); err != nil {
+			problems = append(problems, fmt.Errorf("invalid file pattern `%v`: %v", t.File, err))
+		}
+	}
+
+	if f := t.RolloutFraction; f != nil && (*f <= 0 || *f > 1) {
+		problems = append(problems, fmt.Errorf("the rollout fraction must be between 0 and 1 (got %v)", *f))
+	}
+
+	return problems
+}
@@ -0,0 +1,283 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testYAML = `
+defaults:
+  ownerName: owner
+  notify: true
+  releaseNotesFile: notes.md
+
+targets:
+  ios-beta:
+    appName: app-ios
+    file: build/*.ipa
+    groupName: [Beta, QA]
+    symbols: build/App.xcarchive
+  android-prod:
+    ownerName: other-owner
+    appName: app-android
+    file: build/app.aab
+    storeName: [Production]
+    rolloutFraction: 0.1
+    releaseNotes: "Bug fixes # and improvements"
+`
+
+const testTOML = `
+# shared settings
+[defaults]
+ownerName = "owner"
+notify = true
+releaseNotesFile = 'notes.md'
+
+[targets.ios-beta]
+appName = "app-ios"
+file = "build/*.ipa"
+groupName = [
+  "Beta", # the testers
+  "QA",
+]
+symbols = "build/App.xcarchive"
+
+[targets."android-prod"]
+ownerName = "other-owner"
+appName = "app-android"
+file = "build/app.aab"
+storeName = ["Production"]
+rolloutFraction = 0.1
+releaseNotes = "Bug fixes # and improvements"
+`
+
+const testJSON = `{
+  "defaults": {"ownerName": "owner", "notify": true, "releaseNotesFile": "notes.md"},
+  "targets": {
+    "ios-beta": {"appName": "app-ios", "file": "build/*.ipa", "groupName": ["Beta", "QA"],
+      "symbols": "build/App.xcarchive"},
+    "android-prod": {"ownerName": "other-owner", "appName": "app-android", "file": "build/app.aab",
+      "storeName": ["Production"], "rolloutFraction": 0.1, "releaseNotes": "Bug fixes # and improvements"}
+  }
+}`
+
+func writeConfig(t *testing.T, name string, content string) (string, func()) {
+	dir, err := ioutil.TempDir("", "config")
+	assert.NoError(t, err)
+
+	path := filepath.Join(dir, name)
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestLoad(t *testing.T) {
+	yes, fraction := true, 0.1
+
+	for name, content := range map[string]string{
+		".appcenter.yml":  testYAML,
+		".appcenter.toml": testTOML,
+		".appcenter.json": testJSON,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path, done := writeConfig(t, name, content)
+			defer done()
+
+			assert.Equal(t, path, Find(filepath.Dir(path)))
+
+			c, err := Load(path)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"android-prod", "ios-beta"}, c.Names())
+
+			ios, err := c.Target("ios-beta")
+			assert.NoError(t, err)
+			assert.Equal(t, Target{
+				OwnerName:        "owner",
+				AppName:          "app-ios",
+				File:             "build/*.ipa",
+				GroupNames:       []string{"Beta", "QA"},
+				Notify:           &yes,
+				ReleaseNotesFile: "notes.md",
+				Symbols:          "build/App.xcarchive",
+			}, ios)
+			assert.Equal(t, filepath.Join(filepath.Dir(path), "build/*.ipa"), c.FilePattern(ios))
+
+			android, err := c.Target("android-prod")
+			assert.NoError(t, err)
+			assert.Equal(t, Target{
+				OwnerName:       "other-owner",
+				AppName:         "app-android",
+				File:            "build/app.aab",
+				StoreNames:      []string{"Production"},
+				Notify:          &yes,
+				RolloutFraction: &fraction,
+				ReleaseNotes:    "Bug fixes # and improvements",
+			}, android)
+
+			_, err = c.Target("unknown")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	testCases := map[string]string{
+		".appcenter.yml":  "targets:\n  ios:\n    application: app\n",
+		".appcenter.json": `{"targets": {"ios": {"appName": 12}}}`,
+		".appcenter.toml": "[targets.ios]\nappName = \"app\nfile = 'a'",
+	}
+
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path, done := writeConfig(t, name, content)
+			defer done()
+
+			_, err := Load(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNotes(t *testing.T) {
+	path, done := writeConfig(t, ".appcenter.yml", testYAML)
+	defer done()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(filepath.Dir(path), "notes.md"), []byte("New features\n"), 0644))
+
+	c, err := Load(path)
+	assert.NoError(t, err)
+
+	ios, _ := c.Target("ios-beta")
+	notes, err := c.Notes(ios)
+	assert.NoError(t, err)
+	assert.Equal(t, "New features", notes)
+
+	android, _ := c.Target("android-prod")
+	notes, err = c.Notes(android)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bug fixes # and improvements", notes)
+
+	assert.Empty(t, c.Validate())
+}
+
+func TestValidate(t *testing.T) {
+	path, done := writeConfig(t, ".appcenter.yml", `
+defaults:
+  releaseNotes: notes
+  releaseNotesFile: missing.md
+targets:
+  ios:
+    appName: app
+    file: "build/[.ipa"
+    rolloutFraction: 2
+`)
+	defer done()
+
+	c, err := Load(path)
+	assert.NoError(t, err)
+
+	problems := []string{}
+	for _, p := range c.Validate() {
+		problems = append(problems, p.Error())
+	}
+
+	assert.Equal(t, []string{
+		"defaults: releaseNotes and releaseNotesFile are exclusive",
+		"defaults: release notes file `missing.md` not found",
+		"target 'ios': the owner name and app name are required",
+		"target 'ios': releaseNotes and releaseNotesFile are exclusive",
+		"target 'ios': release notes file `missing.md` not found",
+		"target 'ios': invalid file pattern `build/[.ipa`: syntax error in pattern",
+		"target 'ios': the rollout fraction must be between 0 and 1 (got 2)",
+	}, problems)
+}
+
+func TestLoadTOML(t *testing.T) {
+	path, done := writeConfig(t, ".appcenter.toml", `
+[targets.ios]
+appName = "app-ios" # the [beta] app
+file = "build/[Ii]nternal/*.ipa"
+groupName = [
+  "Beta [internal]", # the ] of the value does not close the array
+  'QA ]',
+]
+releaseNotes = """
+First line
+Second "quoted" line"""
+symbols = '''build\App.xcarchive'''
+
+[targets.android]
+appName = "app-android"
+releaseNotes = '''
+Bug fixes # and improvements
+'''
+`)
+	defer done()
+
+	c, err := Load(path)
+	assert.NoError(t, err)
+
+	ios, err := c.Target("ios")
+	assert.NoError(t, err)
+	assert.Equal(t, Target{
+		AppName:      "app-ios",
+		File:         "build/[Ii]nternal/*.ipa",
+		GroupNames:   []string{"Beta [internal]", "QA ]"},
+		ReleaseNotes: "First line\nSecond \"quoted\" line",
+		Symbols:      `build\App.xcarchive`,
+	}, ios)
+
+	android, err := c.Target("android")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bug fixes # and improvements\n", android.ReleaseNotes)
+}
+
+func TestSave(t *testing.T) {
+	yes, fraction := true, 0.5
+
+	for name, content := range map[string]string{
+		".appcenter.yml":  testYAML,
+		".appcenter.toml": testTOML,
+		".appcenter.json": testJSON,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path, done := writeConfig(t, name, content)
+			defer done()
+
+			c, err := Load(path)
+			assert.NoError(t, err)
+
+			target := Target{
+				OwnerName:       "owner",
+				AppName:         "app \"windows\"",
+				File:            "build/*.msix",
+				GroupNames:      []string{"Beta"},
+				Mandatory:       &yes,
+				RolloutFraction: &fraction,
+				ReleaseNotes:    "line 1\nline 2",
+			}
+			c.SetTarget("windows.beta", target)
+			assert.NoError(t, c.Save())
+
+			saved, err := Load(path)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"android-prod", "ios-beta", "windows.beta"}, saved.Names())
+			assert.Equal(t, c.Defaults, saved.Defaults)
+			assert.Equal(t, c.Targets, saved.Targets)
+		})
+	}
+
+	path, done := writeConfig(t, ".appcenter.yml", "")
+	defer done()
+
+	c := &Config{Path: path}
+	c.SetTarget("ios", Target{OwnerName: "owner", AppName: "app"})
+	assert.NoError(t, c.Save())
+
+	saved, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]Target{"ios": {OwnerName: "owner", AppName: "app"}}, saved.Targets)
+}
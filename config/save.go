@@ -0,0 +1,178 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetTarget adds or replaces the named target
+func (c *Config) SetTarget(name string, t Target) {
+	if c.Targets == nil {
+		c.Targets = map[string]Target{}
+	}
+
+	c.Targets[name] = t
+}
+
+// Save writes the configuration to its file, in the format of its extension. The file is
+// rewritten as a whole: its comments are not kept.
+func (c *Config) Save() error {
+	var data []byte
+	var err error
+
+	switch strings.ToLower(filepath.Ext(c.Path)) {
+	case ".toml":
+		data = c.encodeTOML()
+	case ".json":
+		data, err = json.MarshalIndent(c, "", "  ")
+		data = append(data, '\n')
+	default:
+		var buf bytes.Buffer
+		err = EncodeYAML(&buf, c)
+		data = buf.Bytes()
+	}
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(c.Path, data, 0644)
+}
+
+// EncodeYAML writes the value as YAML through its JSON representation, keeping the names and the
+// order of its fields
+func EncodeYAML(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	// JSON being YAML, it is decoded as a node keeping the order of the fields, then written
+	// in the block style
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+func (c *Config) encodeTOML() []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("[defaults]\n")
+	writeTOMLTarget(&buf, c.Defaults)
+
+	for _, name := range c.Names() {
+		fmt.Fprintf(&buf, "\n[targets.%v]\n", tomlKey(name))
+		writeTOMLTarget(&buf, c.Targets[name])
+	}
+
+	return buf.Bytes()
+}
+
+// writeTOMLTarget writes the settings of the target, in the order of the fields and with their
+// JSON names. The empty settings are omitted.
+func writeTOMLTarget(buf *bytes.Buffer, t Target) {
+	v := reflect.ValueOf(t)
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if f.IsZero() {
+			continue
+		}
+
+		name := strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+		fmt.Fprintf(buf, "%v = %v\n", name, tomlValue(reflect.Indirect(f)))
+	}
+}
+
+func tomlValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return tomlString(v.String())
+
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+
+	case reflect.Float64:
+		s := strconv.FormatFloat(v.Float(), 'f', -1, 64)
+		if !strings.Contains(s, ".") && !math.IsInf(v.Float(), 0) && !math.IsNaN(v.Float()) {
+			s += ".0"
+		}
+		return s
+
+	case reflect.Slice:
+		values := []string{}
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, tomlValue(v.Index(i)))
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	}
+
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// tomlString returns the basic TOML string of the value
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	b.WriteByte('"')
+	return b.String()
+}
+
+// tomlKey returns the key, quoted if it is not a bare key
+func tomlKey(k string) string {
+	for _, r := range k {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return tomlString(k)
+		}
+	}
+
+	if k == "" {
+		return `""`
+	}
+
+	return k
+}
@@ -0,0 +1,112 @@
+package inspect
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+func inspectAPK(a *archive) (*Info, error) {
+	data, _, err := a.readFile(zipFileNamed("AndroidManifest.xml"))
+	if err == os.ErrNotExist {
+		return nil, fmt.Errorf("No AndroidManifest.xml found in the APK")
+	} else if err != nil {
+		return nil, err
+	}
+
+	manifest, err := parseAXML(data)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := androidInfo(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	info.SignatureSchemes, info.CertificateSHA256 = a.apkSignatures()
+	info.Signed = len(info.SignatureSchemes) > 0
+	info.ABIs = a.nativeABIs(false)
+	return info, nil
+}
+
+func inspectAAB(a *archive) (*Info, error) {
+	data, _, err := a.readFile(zipFileNamed("base/manifest/AndroidManifest.xml"))
+	if err == os.ErrNotExist {
+		return nil, fmt.Errorf("No base/manifest/AndroidManifest.xml found in the AAB")
+	} else if err != nil {
+		return nil, err
+	}
+
+	manifest, err := parseProtoXML(data)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := androidInfo(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	// bundles are signed with jarsigner
+	if cert, ok := a.jarSignature(); ok {
+		info.SignatureSchemes = []string{SignatureSchemeV1}
+		info.CertificateSHA256 = cert
+	}
+	info.Signed = len(info.SignatureSchemes) > 0
+	info.ABIs = a.nativeABIs(true)
+	return info, nil
+}
+
+func androidInfo(manifest *element) (*Info, error) {
+	if manifest.Name != "manifest" {
+		return nil, fmt.Errorf("Invalid AndroidManifest.xml root element '%v'", manifest.Name)
+	}
+
+	info := &Info{
+		Platform:     PlatformAndroid,
+		Identifier:   manifest.Attrs["package"],
+		BuildVersion: manifest.Attrs["versionName"],
+		BuildNumber:  manifest.Attrs["versionCode"],
+	}
+
+	if sdk := manifest.child("uses-sdk"); sdk != nil {
+		info.MinOS = sdk.Attrs["minSdkVersion"]
+		info.TargetSDK = sdk.Attrs["targetSdkVersion"]
+	}
+
+	if app := manifest.child("application"); app != nil {
+		info.Debuggable = app.Attrs["debuggable"] == "true"
+	}
+
+	return info, nil
+}
+
+// nativeABIs returns the ABIs of the native libraries of the archive, stored under lib/<abi>/ in
+// APKs and under <module>/lib/<abi>/ in bundles
+func (a *archive) nativeABIs(bundle bool) []string {
+	found := map[string]bool{}
+	for _, f := range a.File {
+		parts := strings.Split(f.Name, "/")
+		if bundle && len(parts) > 0 {
+			parts = parts[1:]
+		}
+
+		if len(parts) == 3 && parts[0] == "lib" && parts[1] != "" && parts[2] != "" {
+			found[parts[1]] = true
+		}
+	}
+
+	if len(found) == 0 {
+		return nil
+	}
+
+	abis := make([]string, 0, len(found))
+	for abi := range found {
+		abis = append(abis, abi)
+	}
+	sort.Strings(abis)
+
+	return abis
+}
@@ -0,0 +1,242 @@
+package inspect
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	// SignatureSchemeV1 JAR signature
+	SignatureSchemeV1 = "v1"
+
+	// SignatureSchemeV2 APK Signature Scheme v2
+	SignatureSchemeV2 = "v2"
+
+	// SignatureSchemeV3 APK Signature Scheme v3
+	SignatureSchemeV3 = "v3"
+
+	apkSignatureSchemeV2ID = 0x7109871a
+	apkSignatureSchemeV3ID = 0xf05368c0
+)
+
+// apkSigBlockMagic ends the APK Signing Block, holding the v2 and later signatures
+var apkSigBlockMagic = []byte("APK Sig Block 42")
+
+// apkSignatures returns the signature schemes of the APK, and the SHA-256 digest of the
+// certificate of its first signer, taken from the most recent scheme
+func (a *archive) apkSignatures() ([]string, string) {
+	var (
+		schemes []string
+		cert    string
+	)
+
+	if c, ok := a.jarSignature(); ok {
+		schemes = append(schemes, SignatureSchemeV1)
+		cert = c
+	}
+
+	block, err := a.signingBlock()
+	if err != nil {
+		return schemes, cert
+	}
+
+	for _, s := range []struct {
+		id     uint32
+		scheme string
+	}{
+		{apkSignatureSchemeV2ID, SignatureSchemeV2},
+		{apkSignatureSchemeV3ID, SignatureSchemeV3},
+	} {
+		value, ok := block[s.id]
+		if !ok {
+			continue
+		}
+
+		schemes = append(schemes, s.scheme)
+		if c, err := signerCertificate(value); err == nil {
+			cert = certificateDigest(c)
+		}
+	}
+
+	return schemes, cert
+}
+
+// jarSignature returns the SHA-256 digest of the signing certificate of a JAR signature, and
+// whether the archive has such a signature
+func (a *archive) jarSignature() (string, bool) {
+	data, _, err := a.readFile(isJARSignature)
+	if err != nil {
+		return "", false
+	}
+
+	c, err := cmsCertificate(data)
+	if err != nil {
+		return "", true
+	}
+
+	return certificateDigest(c), true
+}
+
+// isJARSignature returns true for the signature block files of a JAR signature (v1 scheme)
+func isJARSignature(name string) bool {
+	if path.Dir(name) != "META-INF" {
+		return false
+	}
+
+	switch strings.ToUpper(path.Ext(name)) {
+	case ".RSA", ".DSA", ".EC":
+		return true
+	}
+	return false
+}
+
+func certificateDigest(cert []byte) string {
+	sum := sha256.Sum256(cert)
+	return hex.EncodeToString(sum[:])
+}
+
+// cmsCertificate returns the first certificate of a DER encoded CMS SignedData envelope
+func cmsCertificate(data []byte) ([]byte, error) {
+	sd, err := cmsSignedDataOf(data)
+	if err != nil {
+		return nil, err
+	}
+
+	certs := sd.Certificates
+	if certs.Class != asn1.ClassContextSpecific || certs.Tag != 0 {
+		return nil, fmt.Errorf("No certificate in the CMS envelope")
+	}
+
+	var cert asn1.RawValue
+	if _, err := asn1.Unmarshal(certs.Bytes, &cert); err != nil {
+		return nil, err
+	}
+
+	return cert.FullBytes, nil
+}
+
+// centralDirectoryOffset returns the offset of the zip central directory, read from the end of
+// central directory record
+func (a *archive) centralDirectoryOffset() (int64, error) {
+	// the record is 22 bytes long, followed by a comment of at most 65535 bytes
+	size := int64(22 + 65535)
+	if size > a.size {
+		size = a.size
+	}
+
+	tail := make([]byte, size)
+	if _, err := a.file.ReadAt(tail, a.size-size); err != nil {
+		return 0, err
+	}
+
+	i := bytes.LastIndex(tail, []byte{0x50, 0x4b, 0x05, 0x06})
+	if i < 0 || i+22 > len(tail) {
+		return 0, fmt.Errorf("No zip end of central directory record found")
+	}
+
+	return int64(binary.LittleEndian.Uint32(tail[i+16:])), nil
+}
+
+// signingBlock returns the ID-value pairs of the APK Signing Block, stored right before the
+// central directory:
+//
+//	size of the block (uint64), pairs of length (uint64) ID (uint32) value, size of the block (uint64), magic
+func (a *archive) signingBlock() (map[uint32][]byte, error) {
+	offset, err := a.centralDirectoryOffset()
+	if err != nil {
+		return nil, err
+	}
+
+	footer := make([]byte, 8+len(apkSigBlockMagic))
+	if offset < int64(len(footer)) {
+		return nil, fmt.Errorf("No APK Signing Block found")
+	}
+
+	if _, err := a.file.ReadAt(footer, offset-int64(len(footer))); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(footer[8:], apkSigBlockMagic) {
+		return nil, fmt.Errorf("No APK Signing Block found")
+	}
+
+	size := int64(binary.LittleEndian.Uint64(footer))
+	if size < int64(len(footer)) || size > offset-8 {
+		return nil, fmt.Errorf("Invalid APK Signing Block size")
+	}
+
+	pairs := make([]byte, size-int64(len(footer)))
+	if _, err := a.file.ReadAt(pairs, offset-size); err != nil {
+		return nil, err
+	}
+
+	block := map[uint32][]byte{}
+	for len(pairs) > 0 {
+		if len(pairs) < 12 {
+			return nil, fmt.Errorf("Invalid APK Signing Block pair")
+		}
+
+		length := binary.LittleEndian.Uint64(pairs)
+		if length < 4 || length > uint64(len(pairs)-8) {
+			return nil, fmt.Errorf("Invalid APK Signing Block pair length")
+		}
+
+		block[binary.LittleEndian.Uint32(pairs[8:])] = pairs[12 : 8+length]
+		pairs = pairs[8+length:]
+	}
+
+	return block, nil
+}
+
+// signerCertificate returns the first certificate of the first signer of a v2 or v3 signature:
+//
+//	signers > signer > signed data > (digests, certificates > certificate)
+func signerCertificate(value []byte) ([]byte, error) {
+	signers, _, err := lengthPrefixed(value)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, _, err := lengthPrefixed(signers)
+	if err != nil {
+		return nil, err
+	}
+
+	signedData, _, err := lengthPrefixed(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	_, rest, err := lengthPrefixed(signedData)
+	if err != nil {
+		return nil, err
+	}
+
+	certs, _, err := lengthPrefixed(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, _, err := lengthPrefixed(certs)
+	return cert, err
+}
+
+// lengthPrefixed splits a value prefixed by its length (uint32) from the data following it
+func lengthPrefixed(b []byte) ([]byte, []byte, error) {
+	if len(b) < 4 {
+		return nil, nil, fmt.Errorf("Truncated length prefixed value")
+	}
+
+	length := binary.LittleEndian.Uint32(b)
+	if uint64(length) > uint64(len(b)-4) {
+		return nil, nil, fmt.Errorf("Truncated length prefixed value")
+	}
+
+	return b[4 : 4+length], b[4+length:], nil
+}
@@ -0,0 +1,55 @@
+package inspect
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPKSignatures(t *testing.T) {
+	// signers > signer > signed data > (digests, certificates > certificate)
+	signedData := append(encodeLengthPrefixed(), encodeLengthPrefixed(encodeLengthPrefixed(testCertificate))...)
+	signature := encodeLengthPrefixed(encodeLengthPrefixed(encodeLengthPrefixed(signedData)))
+
+	testCases := []struct {
+		name     string
+		pairs    map[uint32][]byte
+		schemes  []string
+		expected string
+	}{
+		{"v2", map[uint32][]byte{apkSignatureSchemeV2ID: signature, 0x42726577: {0, 0, 0, 0}},
+			[]string{SignatureSchemeV2}, testCertificateSHA256},
+		{"v2 and v3", map[uint32][]byte{apkSignatureSchemeV2ID: signature, apkSignatureSchemeV3ID: signature},
+			[]string{SignatureSchemeV2, SignatureSchemeV3}, testCertificateSHA256},
+		{"Invalid signature", map[uint32][]byte{apkSignatureSchemeV2ID: {1, 2}},
+			[]string{SignatureSchemeV2}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, done := writeZip(t, "app.apk", map[string][]byte{"AndroidManifest.xml": encodeAXML(testManifest)})
+			defer done()
+
+			data, err := ioutil.ReadFile(path)
+			assert.NoError(t, err)
+			assert.NoError(t, ioutil.WriteFile(path, encodeSigningBlock(t, data, tc.pairs), 0644))
+
+			info, err := Inspect(path)
+			assert.NoError(t, err)
+			assert.True(t, info.Signed)
+			assert.Equal(t, tc.schemes, info.SignatureSchemes)
+			assert.Equal(t, tc.expected, info.CertificateSHA256)
+		})
+	}
+
+	t.Run("Unsigned APK", func(t *testing.T) {
+		path, done := writeZip(t, "app.apk", map[string][]byte{"AndroidManifest.xml": encodeAXML(testManifest)})
+		defer done()
+
+		info, err := Inspect(path)
+		assert.NoError(t, err)
+		assert.False(t, info.Signed)
+		assert.Nil(t, info.SignatureSchemes)
+	})
+}
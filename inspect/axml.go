@@ -0,0 +1,261 @@
+package inspect
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"unicode/utf16"
+)
+
+// element is a minimal XML element tree, shared by the Android binary XML and protobuf XML parsers
+type element struct {
+	Name     string
+	Attrs    map[string]string
+	Children []*element
+}
+
+// child returns the first child element with the provided name
+func (e *element) child(name string) *element {
+	for _, c := range e.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+const (
+	axmlStringPoolType  = 0x0001
+	axmlXMLType         = 0x0003
+	axmlStartElement    = 0x0102
+	axmlEndElement      = 0x0103
+	axmlResourceMapType = 0x0180
+
+	axmlUTF8Flag = 1 << 8
+	axmlNoIndex  = 0xffffffff
+
+	axmlTypeReference = 0x01
+	axmlTypeString    = 0x03
+	axmlTypeIntHex    = 0x11
+	axmlTypeBoolean   = 0x12
+)
+
+// androidAttributes are the names of the android framework attributes identified by their
+// resource ID, used when the attribute names were stripped by an obfuscator
+var androidAttributes = map[uint32]string{
+	0x0101000f: "debuggable",
+	0x0101020c: "minSdkVersion",
+	0x0101021b: "versionCode",
+	0x0101021c: "versionName",
+	0x01010270: "targetSdkVersion",
+	0x010102b7: "testOnly",
+	0x010104ea: "extractNativeLibs",
+}
+
+// parseAXML decodes an Android binary XML document (as found in APK files) into an element tree
+func parseAXML(data []byte) (*element, error) {
+	if len(data) < 8 || binary.LittleEndian.Uint16(data) != axmlXMLType {
+		return nil, fmt.Errorf("Invalid Android binary XML document")
+	}
+
+	var (
+		strings     []string
+		resourceMap []uint32
+		root        *element
+		stack       []*element
+	)
+
+	headerSize := int(binary.LittleEndian.Uint16(data[2:]))
+	for off := headerSize; off+8 <= len(data); {
+		chunkType := binary.LittleEndian.Uint16(data[off:])
+		chunkSize := int(binary.LittleEndian.Uint32(data[off+4:]))
+		if chunkSize < 8 || off+chunkSize > len(data) {
+			return nil, fmt.Errorf("Invalid Android binary XML chunk at offset %d", off)
+		}
+		chunk := data[off : off+chunkSize]
+
+		switch chunkType {
+		case axmlStringPoolType:
+			var err error
+			if strings, err = parseStringPool(chunk); err != nil {
+				return nil, err
+			}
+
+		case axmlResourceMapType:
+			hs := int(binary.LittleEndian.Uint16(chunk[2:]))
+			for i := hs; i+4 <= len(chunk); i += 4 {
+				resourceMap = append(resourceMap, binary.LittleEndian.Uint32(chunk[i:]))
+			}
+
+		case axmlStartElement:
+			e, err := parseAXMLElement(chunk, strings, resourceMap)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(stack) == 0 {
+				root = e
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, e)
+			}
+			stack = append(stack, e)
+
+		case axmlEndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+
+		off += chunkSize
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("Android binary XML document has no element")
+	}
+
+	return root, nil
+}
+
+func parseAXMLElement(chunk []byte, strings []string, resourceMap []uint32) (*element, error) {
+	if len(chunk) < 36 {
+		return nil, fmt.Errorf("Invalid Android binary XML element")
+	}
+
+	str := func(i uint32) string {
+		if int64(i) < int64(len(strings)) {
+			return strings[i]
+		}
+		return ""
+	}
+
+	e := &element{
+		Name:  str(binary.LittleEndian.Uint32(chunk[20:])),
+		Attrs: map[string]string{},
+	}
+
+	attrStart := 16 + int(binary.LittleEndian.Uint16(chunk[24:]))
+	attrSize := int(binary.LittleEndian.Uint16(chunk[26:]))
+	attrCount := int(binary.LittleEndian.Uint16(chunk[28:]))
+	if attrSize < 20 {
+		attrSize = 20
+	}
+
+	for i := 0; i < attrCount; i++ {
+		a := attrStart + i*attrSize
+		if a+20 > len(chunk) {
+			return nil, fmt.Errorf("Invalid Android binary XML attribute")
+		}
+
+		nameIndex := binary.LittleEndian.Uint32(chunk[a+4:])
+		raw := binary.LittleEndian.Uint32(chunk[a+8:])
+		dataType := chunk[a+15]
+		value := binary.LittleEndian.Uint32(chunk[a+16:])
+
+		name := str(nameIndex)
+		if int64(nameIndex) < int64(len(resourceMap)) {
+			if n, ok := androidAttributes[resourceMap[nameIndex]]; ok {
+				name = n
+			}
+		}
+
+		if raw != axmlNoIndex {
+			e.Attrs[name] = str(raw)
+			continue
+		}
+
+		switch dataType {
+		case axmlTypeString:
+			e.Attrs[name] = str(value)
+		case axmlTypeBoolean:
+			e.Attrs[name] = strconv.FormatBool(value != 0)
+		case axmlTypeIntHex:
+			e.Attrs[name] = fmt.Sprintf("0x%x", value)
+		case axmlTypeReference:
+			e.Attrs[name] = fmt.Sprintf("@0x%08x", value)
+		default:
+			e.Attrs[name] = strconv.FormatInt(int64(int32(value)), 10)
+		}
+	}
+
+	return e, nil
+}
+
+// parseStringPool decodes a binary XML string pool chunk
+func parseStringPool(chunk []byte) ([]string, error) {
+	if len(chunk) < 28 {
+		return nil, fmt.Errorf("Invalid Android binary XML string pool")
+	}
+
+	headerSize := int(binary.LittleEndian.Uint16(chunk[2:]))
+	count := int(binary.LittleEndian.Uint32(chunk[8:]))
+	flags := binary.LittleEndian.Uint32(chunk[16:])
+	stringsStart := int(binary.LittleEndian.Uint32(chunk[20:]))
+
+	if headerSize+count*4 > len(chunk) || stringsStart > len(chunk) {
+		return nil, fmt.Errorf("Invalid Android binary XML string pool")
+	}
+
+	res := make([]string, count)
+	for i := range res {
+		off := stringsStart + int(binary.LittleEndian.Uint32(chunk[headerSize+i*4:]))
+		if off >= len(chunk) {
+			return nil, fmt.Errorf("Invalid Android binary XML string offset")
+		}
+
+		var err error
+		if flags&axmlUTF8Flag != 0 {
+			res[i], err = decodeUTF8PoolString(chunk[off:])
+		} else {
+			res[i], err = decodeUTF16PoolString(chunk[off:])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func decodeUTF8PoolString(b []byte) (string, error) {
+	// the UTF-16 length is followed by the UTF-8 length, both on one or two bytes
+	_, b = poolLength8(b)
+	n, b := poolLength8(b)
+	if n > len(b) {
+		return "", fmt.Errorf("Invalid Android binary XML string")
+	}
+	return string(b[:n]), nil
+}
+
+func poolLength8(b []byte) (int, []byte) {
+	if len(b) == 0 {
+		return 0, b
+	}
+	if b[0]&0x80 != 0 && len(b) > 1 {
+		return int(b[0]&0x7f)<<8 | int(b[1]), b[2:]
+	}
+	return int(b[0]), b[1:]
+}
+
+func decodeUTF16PoolString(b []byte) (string, error) {
+	if len(b) < 2 {
+		return "", fmt.Errorf("Invalid Android binary XML string")
+	}
+
+	n := int(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if n&0x8000 != 0 && len(b) >= 2 {
+		n = (n&0x7fff)<<16 | int(binary.LittleEndian.Uint16(b))
+		b = b[2:]
+	}
+
+	if n*2 > len(b) {
+		return "", fmt.Errorf("Invalid Android binary XML string")
+	}
+
+	u := make([]uint16, n)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	return string(utf16.Decode(u)), nil
+}
@@ -0,0 +1,234 @@
+package inspect
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf16"
+)
+
+// cfbSignature is the signature of the compound file binary format, used by MSI packages
+var cfbSignature = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
+
+const (
+	cfbEndOfChain  = 0xfffffffe
+	cfbFreeSector  = 0xffffffff
+	cfbStreamEntry = 2
+	cfbRootEntry   = 5
+	cfbMaxSectors  = 1 << 24
+)
+
+// compoundFile is a reader of the streams of a compound file (MS-CFB)
+type compoundFile struct {
+	r              io.ReaderAt
+	sectorSize     int64
+	miniSectorSize int64
+	miniCutoff     uint64
+	fat            []uint32
+	miniFat        []uint32
+	miniStream     []byte
+	entries        []cfbEntry
+}
+
+type cfbEntry struct {
+	Name  string
+	Type  byte
+	Start uint32
+	Size  uint64
+}
+
+func openCompoundFile(r io.ReaderAt) (*compoundFile, error) {
+	header := make([]byte, 512)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(header[:8], cfbSignature) {
+		return nil, fmt.Errorf("Not a compound file")
+	}
+
+	sectorShift := binary.LittleEndian.Uint16(header[30:])
+	miniSectorShift := binary.LittleEndian.Uint16(header[32:])
+	if sectorShift < 7 || sectorShift > 16 || miniSectorShift > sectorShift {
+		return nil, fmt.Errorf("Invalid compound file sector size")
+	}
+
+	cf := &compoundFile{
+		r:              r,
+		sectorSize:     1 << sectorShift,
+		miniSectorSize: 1 << miniSectorShift,
+		miniCutoff:     uint64(binary.LittleEndian.Uint32(header[56:])),
+	}
+
+	numFatSectors := binary.LittleEndian.Uint32(header[44:])
+	firstDirSector := binary.LittleEndian.Uint32(header[48:])
+	firstMiniFatSector := binary.LittleEndian.Uint32(header[60:])
+	firstDifatSector := binary.LittleEndian.Uint32(header[68:])
+
+	if numFatSectors > cfbMaxSectors {
+		return nil, fmt.Errorf("Invalid compound file FAT size")
+	}
+
+	// the FAT sectors are listed in the header, then in the DIFAT sectors chain
+	var fatSectors []uint32
+	for i := 0; i < 109; i++ {
+		fatSectors = append(fatSectors, binary.LittleEndian.Uint32(header[76+i*4:]))
+	}
+
+	for s, n := firstDifatSector, 0; s < cfbEndOfChain && n < cfbMaxSectors; n++ {
+		b, err := cf.sector(s)
+		if err != nil {
+			return nil, err
+		}
+
+		count := len(b)/4 - 1
+		for i := 0; i < count; i++ {
+			fatSectors = append(fatSectors, binary.LittleEndian.Uint32(b[i*4:]))
+		}
+		s = binary.LittleEndian.Uint32(b[count*4:])
+	}
+
+	for _, s := range fatSectors[:minInt(int(numFatSectors), len(fatSectors))] {
+		b, err := cf.sector(s)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i+4 <= len(b); i += 4 {
+			cf.fat = append(cf.fat, binary.LittleEndian.Uint32(b[i:]))
+		}
+	}
+
+	dir, err := cf.chain(firstDirSector, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i+128 <= len(dir); i += 128 {
+		cf.entries = append(cf.entries, parseCFBEntry(dir[i:i+128]))
+	}
+
+	if len(cf.entries) == 0 || cf.entries[0].Type != cfbRootEntry {
+		return nil, fmt.Errorf("Invalid compound file root entry")
+	}
+
+	miniFat, err := cf.chain(firstMiniFatSector, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+4 <= len(miniFat); i += 4 {
+		cf.miniFat = append(cf.miniFat, binary.LittleEndian.Uint32(miniFat[i:]))
+	}
+
+	// the mini stream is stored in the sectors chain of the root entry
+	root := cf.entries[0]
+	if cf.miniStream, err = cf.chain(root.Start, root.Size); err != nil {
+		return nil, err
+	}
+
+	return cf, nil
+}
+
+func parseCFBEntry(b []byte) cfbEntry {
+	nameLen := int(binary.LittleEndian.Uint16(b[64:]))
+	if nameLen > 64 {
+		nameLen = 64
+	}
+
+	// UTF-16 name, including its null terminator
+	u := make([]uint16, 0, 32)
+	for i := 0; i+1 < nameLen-1; i += 2 {
+		u = append(u, binary.LittleEndian.Uint16(b[i:]))
+	}
+
+	return cfbEntry{
+		Name:  string(utf16.Decode(u)),
+		Type:  b[66],
+		Start: binary.LittleEndian.Uint32(b[116:]),
+		Size:  binary.LittleEndian.Uint64(b[120:]),
+	}
+}
+
+func (cf *compoundFile) sector(id uint32) ([]byte, error) {
+	b := make([]byte, cf.sectorSize)
+	if _, err := cf.r.ReadAt(b, (int64(id)+1)*cf.sectorSize); err != nil {
+		return nil, fmt.Errorf("Failed to read compound file sector %d: %v", id, err)
+	}
+	return b, nil
+}
+
+// chain reads the sectors chain starting at the provided sector. When size is not zero, the
+// result is truncated to size
+func (cf *compoundFile) chain(start uint32, size uint64) ([]byte, error) {
+	var buf bytes.Buffer
+	for s, n := start, 0; s != cfbEndOfChain && s != cfbFreeSector; n++ {
+		if int(s) >= len(cf.fat) || n > cfbMaxSectors {
+			return nil, fmt.Errorf("Invalid compound file sectors chain")
+		}
+
+		b, err := cf.sector(s)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+
+		if size > 0 && uint64(buf.Len()) >= size {
+			break
+		}
+		s = cf.fat[s]
+	}
+
+	return truncate(buf.Bytes(), size), nil
+}
+
+func (cf *compoundFile) miniChain(start uint32, size uint64) ([]byte, error) {
+	var buf bytes.Buffer
+	for s, n := start, 0; s != cfbEndOfChain && s != cfbFreeSector; n++ {
+		off := int64(s) * cf.miniSectorSize
+		if int(s) >= len(cf.miniFat) || off+cf.miniSectorSize > int64(len(cf.miniStream)) || n > cfbMaxSectors {
+			return nil, fmt.Errorf("Invalid compound file mini sectors chain")
+		}
+
+		buf.Write(cf.miniStream[off : off+cf.miniSectorSize])
+		if uint64(buf.Len()) >= size {
+			break
+		}
+		s = cf.miniFat[s]
+	}
+
+	return truncate(buf.Bytes(), size), nil
+}
+
+// stream returns the content of the stream with the provided name, or nil if it does not exist
+func (cf *compoundFile) stream(name string) ([]byte, error) {
+	for _, e := range cf.entries {
+		if e.Type != cfbStreamEntry || e.Name != name {
+			continue
+		}
+
+		if e.Size == 0 {
+			return []byte{}, nil
+		}
+
+		if e.Size < cf.miniCutoff {
+			return cf.miniChain(e.Start, e.Size)
+		}
+		return cf.chain(e.Start, e.Size)
+	}
+
+	return nil, nil
+}
+
+func truncate(b []byte, size uint64) []byte {
+	if size > 0 && uint64(len(b)) > size {
+		return b[:size]
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
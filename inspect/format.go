@@ -0,0 +1,117 @@
+package inspect
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// xarMagic starts the XAR archives of the macOS installer packages (.pkg)
+	xarMagic = []byte("xar!")
+
+	// dmgMagic starts the 512 bytes trailer of the Apple disk images (.dmg)
+	dmgMagic = []byte("koly")
+)
+
+// DetectFormat returns the format of the binary at the provided path, read from its signature
+// rather than from its extension: ipa, apk, aab, appx, msix, appxbundle, msixbundle, msi, pkg,
+// dmg or zip for the other zip archives. An empty format is returned when the signature is not
+// recognized.
+func DetectFormat(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	return DetectFormatReader(f, fi.Size(), path)
+}
+
+// DetectFormatReader returns the format of the binary of the provided size and name, read from
+// its signature. The name is only used to tell apart the APPX and MSIX packages.
+func DetectFormatReader(f io.ReaderAt, size int64, name string) (string, error) {
+	header := make([]byte, 8)
+	if size >= int64(len(header)) {
+		if _, err := f.ReadAt(header, 0); err != nil {
+			return "", err
+		}
+	}
+
+	switch {
+	case bytes.Equal(header, cfbSignature):
+		return "msi", nil
+	case bytes.HasPrefix(header, xarMagic):
+		return "pkg", nil
+	case bytes.HasPrefix(header, []byte("PK")):
+		r, err := zip.NewReader(f, size)
+		if err != nil {
+			return "", nil
+		}
+		return zipFormat(r, extension(name)), nil
+	}
+
+	if size >= 512 {
+		trailer := make([]byte, len(dmgMagic))
+		if _, err := f.ReadAt(trailer, size-512); err != nil {
+			return "", err
+		}
+		if bytes.Equal(trailer, dmgMagic) {
+			return "dmg", nil
+		}
+	}
+
+	return "", nil
+}
+
+// Format returns the format of the binary of the provided size and name: the one read from its
+// signature, or the extension of the name when the signature is not specific to a format (plain
+// zip archives, unknown signatures). So an AAB named .apk or a binary read from a name without
+// extension are still recognized.
+func Format(f io.ReaderAt, size int64, name string) string {
+	if format, err := DetectFormatReader(f, size, name); err == nil && format != "" && format != "zip" {
+		return format
+	}
+
+	return extension(name)
+}
+
+// zipFormat returns the format of a zip archive from its entries. APPX and MSIX packages share
+// the same layout, the extension is used to tell them apart.
+func zipFormat(r *zip.Reader, ext string) string {
+	a := &archive{Reader: r}
+
+	switch {
+	case a.has(zipFileNamed("BundleConfig.pb")) || a.has(zipFileNamed("base/manifest/AndroidManifest.xml")):
+		return "aab"
+	case a.has(zipFileNamed("AndroidManifest.xml")):
+		return "apk"
+	case a.has(isAppBundleFile("Info.plist")):
+		return "ipa"
+	case a.has(zipFileNamed("AppxMetadata/AppxBundleManifest.xml")):
+		if ext == "msixbundle" {
+			return ext
+		}
+		return "appxbundle"
+	case a.has(zipFileNamed("AppxManifest.xml")):
+		if ext == "msix" {
+			return ext
+		}
+		return "appx"
+	}
+
+	return "zip"
+}
+
+// extension returns the normalized extension of the path, lower case and without the leading dot
+func extension(path string) string {
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+}
@@ -0,0 +1,66 @@
+package inspect
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFormat(t *testing.T) {
+	t.Run("Zip archives are recognized from their entries", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			files    map[string][]byte
+			expected string
+		}{
+			{"app.apk", map[string][]byte{"AndroidManifest.xml": {}}, "apk"},
+			{"app.apk", map[string][]byte{"BundleConfig.pb": {}, "base/manifest/AndroidManifest.xml": {}}, "aab"},
+			{"app.zip", map[string][]byte{"Payload/App.app/Info.plist": {}}, "ipa"},
+			{"app.ipa", map[string][]byte{"Payload/README": {}}, "zip"},
+			{"app.msix", map[string][]byte{"AppxManifest.xml": {}}, "msix"},
+			{"app.zip", map[string][]byte{"AppxManifest.xml": {}}, "appx"},
+			{"app.msixbundle", map[string][]byte{"AppxMetadata/AppxBundleManifest.xml": {}}, "msixbundle"},
+		}
+
+		for _, tc := range testCases {
+			path, done := writeZip(t, tc.name, tc.files)
+			format, err := DetectFormat(path)
+			done()
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, format, tc.name)
+		}
+	})
+
+	t.Run("Other formats are recognized from their signature", func(t *testing.T) {
+		dmg := make([]byte, 1024)
+		copy(dmg[512:], dmgMagic)
+
+		testCases := []struct {
+			data     []byte
+			expected string
+		}{
+			{encodeCompoundFile(map[string][]byte{}), "msi"},
+			{append([]byte("xar!"), make([]byte, 64)...), "pkg"},
+			{dmg, "dmg"},
+			{[]byte("unknown content"), ""},
+			{[]byte{}, ""},
+		}
+
+		dir, err := ioutil.TempDir("", "inspect")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		for _, tc := range testCases {
+			path := filepath.Join(dir, "binary")
+			assert.NoError(t, ioutil.WriteFile(path, tc.data, 0644))
+
+			format, err := DetectFormat(path)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, format)
+		}
+	})
+}
@@ -0,0 +1,448 @@
+package inspect
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/asn1"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeZip writes a zip archive with the provided files to a temporary directory
+func writeZip(t *testing.T, name string, files map[string][]byte) (string, func()) {
+	dir, err := ioutil.TempDir("", "inspect")
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for n, data := range files {
+		f, err := w.Create(n)
+		assert.NoError(t, err)
+		_, err = f.Write(data)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+
+	path := filepath.Join(dir, name)
+	assert.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0644))
+
+	return path, func() { os.RemoveAll(dir) }
+}
+
+// testAttr is an attribute of a testNode. String values are used when str is set, the integer or
+// boolean value otherwise
+type testAttr struct {
+	name  string
+	resID uint32
+	str   string
+	value int32
+	typ   byte
+}
+
+type testNode struct {
+	name     string
+	attrs    []testAttr
+	children []testNode
+}
+
+// encodeAXML encodes the node tree as an Android binary XML document
+func encodeAXML(root testNode) []byte {
+	var (
+		strings  []string
+		resIDs   []uint32
+		index    = map[string]uint32{}
+		elements bytes.Buffer
+	)
+
+	add := func(s string) uint32 {
+		if i, ok := index[s]; ok {
+			return i
+		}
+		index[s] = uint32(len(strings))
+		strings = append(strings, s)
+		return index[s]
+	}
+
+	// the attributes with a resource ID come first, to match the resource map
+	var collect func(n testNode)
+	collect = func(n testNode) {
+		for _, a := range n.attrs {
+			if a.resID != 0 {
+				if _, ok := index[a.name]; !ok {
+					add(a.name)
+					resIDs = append(resIDs, a.resID)
+				}
+			}
+		}
+		for _, c := range n.children {
+			collect(c)
+		}
+	}
+	collect(root)
+
+	le := binary.LittleEndian
+	u16 := func(b *bytes.Buffer, v uint16) { binary.Write(b, le, v) }
+	u32 := func(b *bytes.Buffer, v uint32) { binary.Write(b, le, v) }
+
+	var encode func(n testNode)
+	encode = func(n testNode) {
+		name := add(n.name)
+		u16(&elements, axmlStartElement)
+		u16(&elements, 16)
+		u32(&elements, uint32(36+20*len(n.attrs)))
+		u32(&elements, 0)
+		u32(&elements, axmlNoIndex)
+		u32(&elements, axmlNoIndex)
+		u32(&elements, name)
+		u16(&elements, 20)
+		u16(&elements, 20)
+		u16(&elements, uint16(len(n.attrs)))
+		u16(&elements, 0)
+		u16(&elements, 0)
+		u16(&elements, 0)
+
+		for _, a := range n.attrs {
+			u32(&elements, axmlNoIndex)
+			u32(&elements, add(a.name))
+			if a.str != "" {
+				s := add(a.str)
+				u32(&elements, s)
+				u16(&elements, 8)
+				elements.WriteByte(0)
+				elements.WriteByte(axmlTypeString)
+				u32(&elements, s)
+			} else {
+				u32(&elements, axmlNoIndex)
+				u16(&elements, 8)
+				elements.WriteByte(0)
+				elements.WriteByte(a.typ)
+				u32(&elements, uint32(a.value))
+			}
+		}
+
+		for _, c := range n.children {
+			encode(c)
+		}
+
+		u16(&elements, axmlEndElement)
+		u16(&elements, 16)
+		u32(&elements, 24)
+		u32(&elements, 0)
+		u32(&elements, axmlNoIndex)
+		u32(&elements, axmlNoIndex)
+		u32(&elements, name)
+	}
+	encode(root)
+
+	var pool bytes.Buffer
+	for _, s := range strings {
+		u := utf16.Encode([]rune(s))
+		u16(&pool, uint16(len(u)))
+		for _, c := range u {
+			u16(&pool, c)
+		}
+		u16(&pool, 0)
+	}
+	for pool.Len()%4 != 0 {
+		pool.WriteByte(0)
+	}
+
+	var chunks bytes.Buffer
+	u16(&chunks, axmlStringPoolType)
+	u16(&chunks, 28)
+	u32(&chunks, uint32(28+4*len(strings)+pool.Len()))
+	u32(&chunks, uint32(len(strings)))
+	u32(&chunks, 0)
+	u32(&chunks, 0)
+	u32(&chunks, uint32(28+4*len(strings)))
+	u32(&chunks, 0)
+	offset := uint32(0)
+	for _, s := range strings {
+		u32(&chunks, offset)
+		offset += uint32(4 + 2*len(utf16.Encode([]rune(s))))
+	}
+	chunks.Write(pool.Bytes())
+
+	u16(&chunks, axmlResourceMapType)
+	u16(&chunks, 8)
+	u32(&chunks, uint32(8+4*len(resIDs)))
+	for _, id := range resIDs {
+		u32(&chunks, id)
+	}
+
+	chunks.Write(elements.Bytes())
+
+	var doc bytes.Buffer
+	u16(&doc, axmlXMLType)
+	u16(&doc, 8)
+	u32(&doc, uint32(8+chunks.Len()))
+	doc.Write(chunks.Bytes())
+
+	return doc.Bytes()
+}
+
+func protoBytes(b []byte, number int, data []byte) []byte {
+	b = protoVarint(b, uint64(number<<3|2))
+	b = protoVarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+func protoVarint(b []byte, v uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return append(b, buf[:binary.PutUvarint(buf, v)]...)
+}
+
+// encodeProtoXML encodes the node tree as an aapt2 protobuf XmlNode
+func encodeProtoXML(n testNode) []byte {
+	var e []byte
+	e = protoBytes(e, 3, []byte(n.name))
+
+	for _, a := range n.attrs {
+		var attr []byte
+		attr = protoBytes(attr, 2, []byte(a.name))
+		if a.resID != 0 {
+			attr = protoVarint(attr, 5<<3)
+			attr = protoVarint(attr, uint64(a.resID))
+		}
+
+		if a.str != "" {
+			attr = protoBytes(attr, 3, []byte(a.str))
+		} else {
+			field := 6
+			if a.typ == axmlTypeBoolean {
+				field = 8
+			}
+			var prim []byte
+			prim = protoVarint(prim, uint64(field<<3))
+			prim = protoVarint(prim, uint64(uint32(a.value)))
+			attr = protoBytes(attr, 6, protoBytes(nil, 7, prim))
+		}
+
+		e = protoBytes(e, 4, attr)
+	}
+
+	for _, c := range n.children {
+		e = protoBytes(e, 5, encodeProtoXML(c))
+	}
+
+	return protoBytes(nil, 1, e)
+}
+
+// encodeMSIStreamName is the reverse of decodeMSIStreamName
+func encodeMSIStreamName(name string, table bool) string {
+	var res []rune
+	if table {
+		res = append(res, 0x4840)
+	}
+
+	index := func(c byte) rune {
+		return rune(bytes.IndexByte([]byte(msiStreamAlphabet), c))
+	}
+
+	for i := 0; i < len(name); i += 2 {
+		if i+1 < len(name) {
+			res = append(res, 0x3800+index(name[i])+index(name[i+1])<<6)
+		} else {
+			res = append(res, 0x4800+index(name[i]))
+		}
+	}
+
+	return string(res)
+}
+
+// encodeCompoundFile writes a version 3 compound file with the provided streams, all stored in
+// regular sectors
+func encodeCompoundFile(streams map[string][]byte) []byte {
+	const sectorSize = 512
+
+	type entry struct {
+		name  string
+		typ   byte
+		start uint32
+		size  uint64
+	}
+
+	entries := []entry{{name: "Root Entry", typ: cfbRootEntry, start: cfbEndOfChain}}
+	dirSectors := (len(streams) + 1 + 3) / 4
+
+	fat := []uint32{0xfffffffd}
+	for i := 0; i < dirSectors; i++ {
+		fat = append(fat, uint32(len(fat)+1))
+	}
+	fat[len(fat)-1] = cfbEndOfChain
+
+	var data bytes.Buffer
+	for name, content := range streams {
+		e := entry{name: name, typ: cfbStreamEntry, start: uint32(len(fat)), size: uint64(len(content))}
+		sectors := (len(content) + sectorSize - 1) / sectorSize
+		for i := 0; i < sectors; i++ {
+			fat = append(fat, uint32(len(fat)+1))
+		}
+		fat[len(fat)-1] = cfbEndOfChain
+
+		data.Write(content)
+		for data.Len()%sectorSize != 0 {
+			data.WriteByte(0)
+		}
+		entries = append(entries, e)
+	}
+
+	le := binary.LittleEndian
+	header := make([]byte, sectorSize)
+	copy(header, cfbSignature)
+	le.PutUint16(header[24:], 0x3e)
+	le.PutUint16(header[26:], 3)
+	le.PutUint16(header[28:], 0xfffe)
+	le.PutUint16(header[30:], 9)
+	le.PutUint16(header[32:], 6)
+	le.PutUint32(header[44:], 1)
+	le.PutUint32(header[48:], 1)
+	le.PutUint32(header[60:], cfbEndOfChain)
+	le.PutUint32(header[68:], cfbEndOfChain)
+	for i := 0; i < 109; i++ {
+		le.PutUint32(header[76+i*4:], cfbFreeSector)
+	}
+	le.PutUint32(header[76:], 0)
+
+	fatSector := make([]byte, sectorSize)
+	for i := range fatSector[:sectorSize/4] {
+		v := uint32(cfbFreeSector)
+		if i < len(fat) {
+			v = fat[i]
+		}
+		le.PutUint32(fatSector[i*4:], v)
+	}
+
+	dir := make([]byte, dirSectors*sectorSize)
+	for i, e := range entries {
+		b := dir[i*128:]
+		u := utf16.Encode([]rune(e.name))
+		for j, c := range u {
+			le.PutUint16(b[j*2:], c)
+		}
+		le.PutUint16(b[64:], uint16(len(u)*2+2))
+		b[66] = e.typ
+		le.PutUint32(b[68:], cfbFreeSector)
+		le.PutUint32(b[72:], cfbFreeSector)
+		le.PutUint32(b[76:], cfbFreeSector)
+		le.PutUint32(b[116:], e.start)
+		le.PutUint64(b[120:], e.size)
+	}
+
+	return append(append(append(header, fatSector...), dir...), data.Bytes()...)
+}
+
+// encodeCMS wraps the content in a DER encoded CMS SignedData envelope, with the optional
+// certificate and without signers
+func encodeCMS(t *testing.T, content []byte, cert []byte) []byte {
+	var sd cmsSignedData
+	sd.Version = 1
+	sd.DigestAlgorithms = asn1.RawValue{Tag: asn1.TagSet, IsCompound: true}
+	sd.EncapContentInfo.EContentType = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	sd.EncapContentInfo.EContent = content
+	if cert != nil {
+		sd.Certificates = asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: cert}
+	}
+
+	signedData, err := asn1.Marshal(sd)
+	assert.NoError(t, err)
+
+	data, err := asn1.Marshal(struct {
+		ContentType asn1.ObjectIdentifier
+		Content     asn1.RawValue
+	}{
+		oidSignedData,
+		asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedData},
+	})
+	assert.NoError(t, err)
+
+	return data
+}
+
+// testCertificate is a DER encoded element standing for a certificate
+var testCertificate = []byte{0x30, 0x03, 0x02, 0x01, 0x01}
+
+// testCertificateSHA256 is the SHA-256 digest of testCertificate
+const testCertificateSHA256 = "1b65f68a522c858715f5dd951cd0402dc16691778814bf0759822b7a257421d0"
+
+// encodeSigningBlock inserts an APK Signing Block with the provided pairs before the central
+// directory of the zip archive
+func encodeSigningBlock(t *testing.T, data []byte, pairs map[uint32][]byte) []byte {
+	eocd := bytes.LastIndex(data, []byte{0x50, 0x4b, 0x05, 0x06})
+	assert.True(t, eocd >= 0)
+	offset := binary.LittleEndian.Uint32(data[eocd+16:])
+
+	var entries []byte
+	for id, value := range pairs {
+		entries = append(entries, make([]byte, 12)...)
+		binary.LittleEndian.PutUint64(entries[len(entries)-12:], uint64(4+len(value)))
+		binary.LittleEndian.PutUint32(entries[len(entries)-4:], id)
+		entries = append(entries, value...)
+	}
+
+	size := make([]byte, 8)
+	binary.LittleEndian.PutUint64(size, uint64(len(entries)+8+len(apkSigBlockMagic)))
+
+	block := append(append(append(append([]byte{}, size...), entries...), size...), apkSigBlockMagic...)
+
+	res := append(append(append([]byte{}, data[:offset]...), block...), data[offset:]...)
+	binary.LittleEndian.PutUint32(res[eocd+len(block)+16:], offset+uint32(len(block)))
+	return res
+}
+
+// encodeLengthPrefixed prefixes the concatenated values by their length
+func encodeLengthPrefixed(values ...[]byte) []byte {
+	var b []byte
+	for _, v := range values {
+		b = append(b, v...)
+	}
+
+	return append([]byte{byte(len(b)), byte(len(b) >> 8), byte(len(b) >> 16), byte(len(b) >> 24)}, b...)
+}
+
+// encodeMachO encodes a 64 bits little endian Mach-O executable with a LC_UUID load command
+func encodeMachO(cpu uint32, uuid [16]byte) []byte {
+	b := make([]byte, 32+24)
+	binary.LittleEndian.PutUint32(b[0:], 0xfeedfacf)
+	binary.LittleEndian.PutUint32(b[4:], cpu)
+	binary.LittleEndian.PutUint32(b[12:], 2) // MH_EXECUTE
+	binary.LittleEndian.PutUint32(b[16:], 1)
+	binary.LittleEndian.PutUint32(b[20:], 24)
+	binary.LittleEndian.PutUint32(b[32:], machoUUIDCmd)
+	binary.LittleEndian.PutUint32(b[36:], 24)
+	copy(b[40:], uuid[:])
+	return b
+}
+
+// encodeFatMachO encodes a fat Mach-O binary with the provided thin binaries
+func encodeFatMachO(cpus []uint32, binaries ...[]byte) []byte {
+	const align = 12
+	header := make([]byte, 8+20*len(binaries))
+	binary.BigEndian.PutUint32(header[0:], 0xcafebabe)
+	binary.BigEndian.PutUint32(header[4:], uint32(len(binaries)))
+
+	data := []byte{}
+	offset := uint32(1 << align)
+	for i, b := range binaries {
+		arch := header[8+20*i:]
+		binary.BigEndian.PutUint32(arch[0:], cpus[i])
+		binary.BigEndian.PutUint32(arch[8:], offset)
+		binary.BigEndian.PutUint32(arch[12:], uint32(len(b)))
+		binary.BigEndian.PutUint32(arch[16:], align)
+
+		padded := make([]byte, 1<<align)
+		copy(padded, b)
+		data = append(data, padded...)
+		offset += 1 << align
+	}
+
+	res := make([]byte, 1<<align)
+	copy(res, header)
+	return append(res, data...)
+}
@@ -0,0 +1,180 @@
+// Package inspect reads the identity and version of the binaries uploaded to AppCenter
+package inspect
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// PlatformAndroid Android binaries (APK, AAB)
+	PlatformAndroid = "Android"
+
+	// PlatformIOS iOS binaries (IPA)
+	PlatformIOS = "iOS"
+
+	// PlatformWindows Windows binaries (MSI, APPX, MSIX)
+	PlatformWindows = "Windows"
+)
+
+// ErrUnsupportedFormat the binary format can not be inspected
+var ErrUnsupportedFormat = errors.New("Unsupported binary format")
+
+// Info is the description of a binary, as read from the binary itself
+type Info struct {
+	// Format of the binary, its normalized extension (ipa, apk, aab, msi, appx, msix...)
+	Format string `json:"format"`
+
+	// Platform of the binary
+	Platform string `json:"platform"`
+
+	// Identifier is the bundle identifier, package name or product name of the binary
+	Identifier string `json:"identifier,omitempty"`
+
+	// BuildVersion is the user facing version: CFBundleShortVersionString, versionName...
+	BuildVersion string `json:"build_version,omitempty"`
+
+	// BuildNumber is the internal version: CFBundleVersion, versionCode...
+	BuildNumber string `json:"build_number,omitempty"`
+
+	// MinOS is the minimum OS version or API level supported by the binary
+	MinOS string `json:"min_os,omitempty"`
+
+	// TargetSDK is the API level targeted by Android binaries
+	TargetSDK string `json:"target_sdk,omitempty"`
+
+	// ABIs are the native ABIs supported by Android binaries
+	ABIs []string `json:"abis,omitempty"`
+
+	// Debuggable is true for debug builds: android:debuggable set, get-task-allow entitlement...
+	Debuggable bool `json:"debuggable"`
+
+	// Signed is true when the binary has a code signature
+	Signed bool `json:"signed"`
+
+	// SignatureSchemes are the signature schemes of Android binaries: v1 (JAR signature), v2, v3
+	SignatureSchemes []string `json:"signature_schemes,omitempty"`
+
+	// CertificateSHA256 is the SHA-256 digest of the signing certificate of Android binaries
+	CertificateSHA256 string `json:"certificate_sha256,omitempty"`
+
+	// Profile is the provisioning profile embedded in an IPA
+	Profile *ProvisioningProfile `json:"provisioning_profile,omitempty"`
+}
+
+// Inspect reads the description of the binary at the provided path. ErrUnsupportedFormat is
+// returned for the binary formats which can not be inspected
+func Inspect(path string) (*Info, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to inspect '%v': %v", filepath.Base(path), err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to inspect '%v': %v", filepath.Base(path), err)
+	}
+
+	return InspectReader(f, fi.Size(), path)
+}
+
+// InspectReader reads the description of the binary of the provided size and name, the format
+// being resolved from its signature (see Format). ErrUnsupportedFormat is returned for the binary
+// formats which can not be inspected
+func InspectReader(r io.ReaderAt, size int64, name string) (*Info, error) {
+	format := Format(r, size, name)
+
+	var (
+		info *Info
+		err  error
+	)
+
+	switch format {
+	case "ipa":
+		info, err = inspectZip(r, size, inspectIPA)
+	case "apk":
+		info, err = inspectZip(r, size, inspectAPK)
+	case "aab":
+		info, err = inspectZip(r, size, inspectAAB)
+	case "appx", "msix", "appxbundle", "msixbundle":
+		info, err = inspectZip(r, size, inspectAppx)
+	case "msi":
+		info, err = inspectMSI(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to inspect '%v': %v", filepath.Base(name), err)
+	}
+
+	info.Format = format
+	return info, nil
+}
+
+// supported returns true if the format can be inspected
+func supported(format string) bool {
+	switch format {
+	case "ipa", "apk", "aab", "appx", "msix", "appxbundle", "msixbundle", "msi":
+		return true
+	}
+	return false
+}
+
+// archive is an opened zip based binary
+type archive struct {
+	*zip.Reader
+	file io.ReaderAt
+	size int64
+}
+
+func inspectZip(f io.ReaderAt, size int64, fn func(*archive) (*Info, error)) (*Info, error) {
+	r, err := zip.NewReader(f, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&archive{Reader: r, file: f, size: size})
+}
+
+// readFile returns the content of the first file of the archive accepted by match
+func (a *archive) readFile(match func(name string) bool) ([]byte, string, error) {
+	for _, f := range a.File {
+		if !match(f.Name) {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, f.Name, err
+		}
+		defer rc.Close()
+
+		b, err := ioutil.ReadAll(rc)
+		return b, f.Name, err
+	}
+
+	return nil, "", os.ErrNotExist
+}
+
+// has returns true if the archive contains a file accepted by match
+func (a *archive) has(match func(name string) bool) bool {
+	for _, f := range a.File {
+		if match(f.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func zipFileNamed(name string) func(string) bool {
+	return func(n string) bool {
+		return n == name
+	}
+}
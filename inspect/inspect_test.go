@@ -0,0 +1,196 @@
+package inspect
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testManifest = testNode{
+	name: "manifest",
+	attrs: []testAttr{
+		{name: "versionCode", resID: 0x0101021b, value: 45, typ: 0x10},
+		{name: "versionName", resID: 0x0101021c, str: "1.2.3"},
+		{name: "package", str: "com.test.app"},
+	},
+	children: []testNode{
+		{name: "uses-sdk", attrs: []testAttr{
+			{name: "minSdkVersion", resID: 0x0101020c, value: 21, typ: 0x10},
+			{name: "targetSdkVersion", resID: 0x01010270, value: 30, typ: 0x10},
+		}},
+		{name: "application", attrs: []testAttr{
+			{name: "debuggable", resID: 0x0101000f, value: -1, typ: axmlTypeBoolean},
+		}},
+	},
+}
+
+const testProfile = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>Name</key>
+	<string>Test Profile</string>
+	<key>UUID</key>
+	<string>1234-5678</string>
+	<key>TeamName</key>
+	<string>Test Team</string>
+	<key>TeamIdentifier</key>
+	<array>
+		<string>ABCDE12345</string>
+	</array>
+	<key>ExpirationDate</key>
+	<date>2030-01-02T03:04:05Z</date>
+	<key>ProvisionedDevices</key>
+	<array>
+		<string>00008030-0001</string>
+		<string>00008030-0002</string>
+	</array>
+	<key>Entitlements</key>
+	<dict>
+		<key>get-task-allow</key>
+		<true/>
+	</dict>
+</dict>
+</plist>`
+
+var testProvisioningProfile = &ProvisioningProfile{
+	Name:           "Test Profile",
+	UUID:           "1234-5678",
+	Type:           ProfileDevelopment,
+	TeamID:         "ABCDE12345",
+	TeamName:       "Test Team",
+	ExpirationDate: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+	Devices:        []string{"00008030-0001", "00008030-0002"},
+	Entitlements:   map[string]interface{}{"get-task-allow": true},
+}
+
+func TestInspect(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string][]byte
+		expected Info
+	}{
+		{
+			"app.ipa",
+			map[string][]byte{
+				"Payload/App.app/Info.plist":                   []byte(xmlPlist),
+				"Payload/App.app/Frameworks/a.plist":           []byte("not a plist"),
+				"Payload/App.app/_CodeSignature/CodeResources": []byte(xmlPlist),
+				"Payload/App.app/embedded.mobileprovision":     encodeCMS(t, []byte(testProfile), nil),
+			},
+			Info{Format: "ipa", Platform: PlatformIOS, Identifier: "com.test.app", BuildVersion: "1.2.3", BuildNumber: "45",
+				Debuggable: true, Signed: true, Profile: testProvisioningProfile},
+		},
+		{
+			"app.apk",
+			map[string][]byte{
+				"AndroidManifest.xml":       encodeAXML(testManifest),
+				"META-INF/CERT.RSA":         encodeCMS(t, []byte{}, testCertificate),
+				"lib/x86/libtest.so":        {},
+				"lib/arm64-v8a/libtest.so":  {},
+				"lib/arm64-v8a/libother.so": {},
+			},
+			Info{Format: "apk", Platform: PlatformAndroid, Identifier: "com.test.app",
+				BuildVersion: "1.2.3", BuildNumber: "45", MinOS: "21", TargetSDK: "30",
+				ABIs: []string{"arm64-v8a", "x86"}, Debuggable: true, Signed: true,
+				SignatureSchemes: []string{SignatureSchemeV1}, CertificateSHA256: testCertificateSHA256},
+		},
+		{
+			"app.aab",
+			map[string][]byte{
+				"base/manifest/AndroidManifest.xml": encodeProtoXML(testManifest),
+				"base/lib/armeabi-v7a/libtest.so":   {},
+			},
+			Info{Format: "aab", Platform: PlatformAndroid, Identifier: "com.test.app",
+				BuildVersion: "1.2.3", BuildNumber: "45", MinOS: "21", TargetSDK: "30",
+				ABIs: []string{"armeabi-v7a"}, Debuggable: true},
+		},
+		{
+			"app.MSIX",
+			map[string][]byte{"AppxManifest.xml": []byte(`<?xml version="1.0" encoding="utf-8"?>
+				<Package xmlns="http://schemas.microsoft.com/appx/manifest/foundation/windows10">
+					<Identity Name="Test.App" Publisher="CN=Test" Version="1.2.3.0" />
+					<Dependencies>
+						<TargetDeviceFamily Name="Windows.Desktop" MinVersion="10.0.17763.0" />
+					</Dependencies>
+				</Package>`)},
+			Info{Format: "msix", Platform: PlatformWindows, Identifier: "Test.App",
+				BuildVersion: "1.2.3.0", MinOS: "10.0.17763.0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, done := writeZip(t, tc.name, tc.files)
+			defer done()
+
+			info, err := Inspect(path)
+			assert.NoError(t, err)
+			assert.Equal(t, &tc.expected, info)
+		})
+	}
+
+	t.Run("Missing manifests should be reported", func(t *testing.T) {
+		path, done := writeZip(t, "app.apk", map[string][]byte{"classes.dex": {}})
+		defer done()
+
+		_, err := Inspect(path)
+		assert.EqualError(t, err, "Failed to inspect 'app.apk': No AndroidManifest.xml found in the APK")
+	})
+
+	t.Run("The format should be read from the signature", func(t *testing.T) {
+		files := map[string][]byte{
+			"base/manifest/AndroidManifest.xml": encodeProtoXML(testManifest),
+		}
+		for _, name := range []string{"app.apk", "app"} {
+			path, done := writeZip(t, name, files)
+			info, err := Inspect(path)
+			done()
+
+			assert.NoError(t, err)
+			assert.Equal(t, "aab", info.Format, name)
+		}
+	})
+
+	t.Run("Unsupported formats should be reported", func(t *testing.T) {
+		path, done := writeZip(t, "app.dmg", map[string][]byte{"README": {}})
+		defer done()
+
+		_, err := Inspect(path)
+		assert.Equal(t, ErrUnsupportedFormat, err)
+	})
+}
+
+func TestInspectMSI(t *testing.T) {
+	strings := []string{"ProductName", "Test App", "ProductVersion", "1.2.3", "Manufacturer", "Test"}
+
+	pool := make([]byte, 4, 4+4*len(strings))
+	binary.LittleEndian.PutUint16(pool, 1252)
+	data := []byte{}
+	for _, s := range strings {
+		pool = append(pool, byte(len(s)), byte(len(s)>>8), 1, 0)
+		data = append(data, s...)
+	}
+
+	// Property table: the name column, then the value column
+	property := []byte{1, 0, 3, 0, 5, 0, 2, 0, 4, 0, 6, 0}
+
+	dir, err := ioutil.TempDir("", "inspect")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.msi")
+	assert.NoError(t, ioutil.WriteFile(path, encodeCompoundFile(map[string][]byte{
+		encodeMSIStreamName("_StringPool", true): pool,
+		encodeMSIStreamName("_StringData", true): data,
+		encodeMSIStreamName("Property", true):    property,
+	}), 0644))
+
+	info, err := Inspect(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &Info{Format: "msi", Platform: PlatformWindows, Identifier: "Test App", BuildVersion: "1.2.3"}, info)
+}
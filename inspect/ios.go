@@ -0,0 +1,51 @@
+package inspect
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// isAppBundleFile returns true if the path is a file of the application bundle of an IPA:
+// Payload/<name>.app/<file>
+func isAppBundleFile(file string) func(string) bool {
+	return func(name string) bool {
+		parts := strings.SplitN(name, "/", 3)
+		return len(parts) == 3 && parts[0] == "Payload" && strings.HasSuffix(parts[1], ".app") && parts[2] == file
+	}
+}
+
+func inspectIPA(a *archive) (*Info, error) {
+	data, _, err := a.readFile(isAppBundleFile("Info.plist"))
+	if err == os.ErrNotExist {
+		return nil, fmt.Errorf("No Payload/*.app/Info.plist found in the IPA")
+	} else if err != nil {
+		return nil, err
+	}
+
+	plist, err := parsePlistDict(data)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{
+		Platform:     PlatformIOS,
+		Identifier:   plistString(plist, "CFBundleIdentifier"),
+		BuildVersion: plistString(plist, "CFBundleShortVersionString"),
+		BuildNumber:  plistString(plist, "CFBundleVersion"),
+		MinOS:        plistString(plist, "MinimumOSVersion"),
+		Signed:       a.has(isAppBundleFile("_CodeSignature/CodeResources")),
+	}
+
+	data, _, err = a.readFile(isAppBundleFile("embedded.mobileprovision"))
+	if err == nil {
+		if info.Profile, err = ParseProvisioningProfile(data); err != nil {
+			return nil, fmt.Errorf("Invalid embedded.mobileprovision: %v", err)
+		}
+
+		// development builds can be attached to a debugger
+		info.Debuggable, _ = info.Profile.Entitlements["get-task-allow"].(bool)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,225 @@
+package inspect
+
+import (
+	"archive/zip"
+	"debug/macho"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// machoUUIDCmd is the LC_UUID load command
+const machoUUIDCmd = 0x1b
+
+// maxExecutableSize is the size above which the executables of an IPA are not read
+var maxExecutableSize int64 = 2 << 30
+
+// MachOUUIDs returns the UUIDs of the architectures of a thin or fat Mach-O binary, formatted as
+// dwarfdump does (ex: 0A1B2C3D-...)
+func MachOUUIDs(r io.ReaderAt) ([]string, error) {
+	files := []*macho.File{}
+
+	if fat, err := macho.NewFatFile(r); err == nil {
+		for _, arch := range fat.Arches {
+			files = append(files, arch.File)
+		}
+	} else if err == macho.ErrNotFat {
+		f, err := macho.NewFile(r)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	} else {
+		return nil, err
+	}
+
+	uuids := []string{}
+	for _, f := range files {
+		for _, l := range f.Loads {
+			raw := l.Raw()
+			if len(raw) < 24 || f.ByteOrder.Uint32(raw) != machoUUIDCmd {
+				continue
+			}
+
+			u := raw[8:24]
+			uuids = append(uuids, strings.ToUpper(fmt.Sprintf("%x-%x-%x-%x-%x", u[:4], u[4:6], u[6:8], u[8:10], u[10:])))
+		}
+	}
+
+	return uuids, nil
+}
+
+// IPAUUIDs returns the UUIDs of the executables of an IPA: the main executable, then the ones of
+// the embedded frameworks, app extensions and apps
+func IPAUUIDs(r io.ReaderAt, size int64) ([]string, error) {
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	a := &archive{Reader: z, file: r, size: size}
+
+	data, name, err := a.readFile(isAppBundleFile("Info.plist"))
+	if err != nil {
+		return nil, fmt.Errorf("No Payload/*.app/Info.plist found in the IPA")
+	}
+
+	executable, err := bundleExecutable(data)
+	if err != nil {
+		return nil, err
+	}
+	if executable == "" {
+		return nil, fmt.Errorf("No CFBundleExecutable in the IPA Info.plist")
+	}
+
+	app := path.Dir(name)
+	uuids, err := a.executableUUIDs(path.Join(app, executable))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("Executable '%v' not found in the IPA", executable)
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, bundle := range a.embeddedBundles(app) {
+		data, _, err := a.readFile(isFile(path.Join(bundle, "Info.plist")))
+		if err != nil {
+			continue
+		}
+
+		// bundles without executable, as the resource bundles, have no symbols
+		executable, err := bundleExecutable(data)
+		if err != nil || executable == "" {
+			continue
+		}
+
+		found, err := a.executableUUIDs(path.Join(bundle, executable))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("Invalid executable '%v': %v", path.Join(bundle, executable), err)
+		}
+		uuids = append(uuids, found...)
+	}
+
+	return uuids, nil
+}
+
+// executableUUIDs returns the UUIDs of the executable of the archive. The executable is copied to a
+// temporary file read by the Mach-O parser, instead of being loaded in memory.
+func (a *archive) executableUUIDs(name string) ([]string, error) {
+	for _, f := range a.File {
+		if f.Name != name {
+			continue
+		}
+
+		if f.UncompressedSize64 > uint64(maxExecutableSize) {
+			return nil, fmt.Errorf("executable larger than %d bytes", maxExecutableSize)
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+
+		tmp, err := ioutil.TempFile("", "appcenter-*-"+path.Base(name))
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		// the declared size is not trusted
+		n, err := io.Copy(tmp, io.LimitReader(rc, maxExecutableSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if n > maxExecutableSize {
+			return nil, fmt.Errorf("executable larger than %d bytes", maxExecutableSize)
+		}
+
+		return MachOUUIDs(tmp)
+	}
+
+	return nil, os.ErrNotExist
+}
+
+// embeddedBundles returns the sorted frameworks, app extensions and apps nested in the app bundle
+func (a *archive) embeddedBundles(app string) []string {
+	found := map[string]bool{}
+	for _, f := range a.File {
+		if !strings.HasPrefix(f.Name, app+"/") {
+			continue
+		}
+
+		dir := path.Dir(f.Name)
+		for dir != app && dir != "." {
+			switch path.Ext(dir) {
+			case ".framework", ".appex", ".app":
+				found[dir] = true
+			}
+			dir = path.Dir(dir)
+		}
+	}
+
+	bundles := make([]string, 0, len(found))
+	for b := range found {
+		bundles = append(bundles, b)
+	}
+	sort.Strings(bundles)
+
+	return bundles
+}
+
+// bundleExecutable returns the CFBundleExecutable of the Info.plist of a bundle
+func bundleExecutable(data []byte) (string, error) {
+	plist, err := parsePlistDict(data)
+	if err != nil {
+		return "", err
+	}
+
+	return plistString(plist, "CFBundleExecutable"), nil
+}
+
+// isFile returns a matcher of the file of the archive
+func isFile(file string) func(string) bool {
+	return func(name string) bool {
+		return name == file
+	}
+}
+
+// DSYMUUIDs returns the UUIDs of the DWARF binaries of a .dSYM bundle
+func DSYMUUIDs(dsym string) ([]string, error) {
+	dwarf := filepath.Join(dsym, "Contents", "Resources", "DWARF")
+	entries, err := ioutil.ReadDir(dwarf)
+	if err != nil {
+		return nil, err
+	}
+
+	uuids := []string{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		f, err := os.Open(filepath.Join(dwarf, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		u, err := MachOUUIDs(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid DWARF binary '%v': %v", e.Name(), err)
+		}
+
+		uuids = append(uuids, u...)
+	}
+
+	sort.Strings(uuids)
+	return uuids, nil
+}
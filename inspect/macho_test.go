@@ -0,0 +1,122 @@
+package inspect
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	cpuARM64  = 0x0100000c
+	cpuX86_64 = 0x01000007
+)
+
+var (
+	testUUID1 = [16]byte{0x0a, 0x1b, 0x2c, 0x3d, 0x4e, 0x5f, 0x60, 0x71, 0x82, 0x93, 0xa4, 0xb5, 0xc6, 0xd7, 0xe8, 0xf9}
+	testUUID2 = [16]byte{15: 1}
+	testUUID3 = [16]byte{15: 2}
+)
+
+func TestMachOUUIDs(t *testing.T) {
+	uuids, err := MachOUUIDs(bytes.NewReader(encodeMachO(cpuARM64, testUUID1)))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0A1B2C3D-4E5F-6071-8293-A4B5C6D7E8F9"}, uuids)
+
+	fat := encodeFatMachO([]uint32{cpuARM64, cpuX86_64}, encodeMachO(cpuARM64, testUUID1), encodeMachO(cpuX86_64, testUUID2))
+	uuids, err = MachOUUIDs(bytes.NewReader(fat))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0A1B2C3D-4E5F-6071-8293-A4B5C6D7E8F9", "00000000-0000-0000-0000-000000000001"}, uuids)
+
+	_, err = MachOUUIDs(bytes.NewReader([]byte("not a binary")))
+	assert.Error(t, err)
+}
+
+func TestIPAUUIDs(t *testing.T) {
+	plist := func(executable string) []byte {
+		return []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict><key>CFBundleExecutable</key><string>` + executable + `</string></dict></plist>`)
+	}
+	resources := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict><key>CFBundleIdentifier</key><string>com.example.resources</string></dict></plist>`)
+
+	for name, tc := range map[string]struct {
+		files    map[string][]byte
+		expected []string
+	}{
+		"main executable": {
+			files: map[string][]byte{
+				"Payload/App.app/Info.plist": plist("App"),
+				"Payload/App.app/App":        encodeMachO(cpuARM64, testUUID1),
+			},
+			expected: []string{"0A1B2C3D-4E5F-6071-8293-A4B5C6D7E8F9"},
+		},
+		"embedded binaries": {
+			files: map[string][]byte{
+				"Payload/App.app/Info.plist":                               plist("App"),
+				"Payload/App.app/App":                                      encodeMachO(cpuARM64, testUUID1),
+				"Payload/App.app/Frameworks/Kit.framework/Info.plist":      plist("Kit"),
+				"Payload/App.app/Frameworks/Kit.framework/Kit":             encodeMachO(cpuARM64, testUUID2),
+				"Payload/App.app/PlugIns/Widget.appex/Info.plist":          plist("Widget"),
+				"Payload/App.app/PlugIns/Widget.appex/Widget":              encodeMachO(cpuARM64, testUUID3),
+				"Payload/App.app/Resources.bundle/Info.plist":              resources,
+				"Payload/App.app/Frameworks/Empty.framework/Info.plist":    resources,
+				"Payload/App.app/Frameworks/Empty.framework/Headers/Kit.h": []byte("#import <Foundation/Foundation.h>"),
+			},
+			expected: []string{
+				"0A1B2C3D-4E5F-6071-8293-A4B5C6D7E8F9",
+				"00000000-0000-0000-0000-000000000001",
+				"00000000-0000-0000-0000-000000000002",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path, done := writeZip(t, "app.ipa", tc.files)
+			defer done()
+
+			data, err := ioutil.ReadFile(path)
+			assert.NoError(t, err)
+
+			uuids, err := IPAUUIDs(bytes.NewReader(data), int64(len(data)))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, uuids)
+		})
+	}
+
+	t.Run("Executables larger than the limit should not be read", func(t *testing.T) {
+		defer func(size int64) { maxExecutableSize = size }(maxExecutableSize)
+		maxExecutableSize = 16
+
+		path, done := writeZip(t, "app.ipa", map[string][]byte{
+			"Payload/App.app/Info.plist": plist("App"),
+			"Payload/App.app/App":        encodeMachO(cpuARM64, testUUID1),
+		})
+		defer done()
+
+		data, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+
+		_, err = IPAUUIDs(bytes.NewReader(data), int64(len(data)))
+		assert.EqualError(t, err, "executable larger than 16 bytes")
+	})
+}
+
+func TestDSYMUUIDs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "inspect")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dwarf := filepath.Join(dir, "App.app.dSYM", "Contents", "Resources", "DWARF")
+	assert.NoError(t, os.MkdirAll(dwarf, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dwarf, "App"), encodeMachO(cpuARM64, testUUID1), 0644))
+
+	uuids, err := DSYMUUIDs(filepath.Join(dir, "App.app.dSYM"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0A1B2C3D-4E5F-6071-8293-A4B5C6D7E8F9"}, uuids)
+
+	_, err = DSYMUUIDs(dir)
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package inspect
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// msiStreamAlphabet is the alphabet used to compress the MSI stream names
+const msiStreamAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz._"
+
+// decodeMSIStreamName decodes the name of a stream of an MSI database. Table streams names are
+// prefixed by "!"
+func decodeMSIStreamName(name string) string {
+	var res []rune
+	for _, r := range name {
+		switch {
+		case r >= 0x3800 && r < 0x4800:
+			r -= 0x3800
+			res = append(res, rune(msiStreamAlphabet[r&0x3f]), rune(msiStreamAlphabet[(r>>6)&0x3f]))
+		case r >= 0x4800 && r < 0x4840:
+			res = append(res, rune(msiStreamAlphabet[r-0x4800]))
+		case r == 0x4840:
+			res = append(res, '!')
+		default:
+			res = append(res, r)
+		}
+	}
+	return string(res)
+}
+
+// msiDatabase reads the tables of an MSI database
+type msiDatabase struct {
+	cf      *compoundFile
+	strings []string
+	refSize int
+}
+
+func openMSIDatabase(r io.ReaderAt) (*msiDatabase, error) {
+	cf, err := openCompoundFile(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range cf.entries {
+		cf.entries[i].Name = decodeMSIStreamName(cf.entries[i].Name)
+	}
+
+	db := &msiDatabase{cf: cf}
+	if err := db.loadStrings(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// loadStrings loads the string pool of the database, indexed by string identifier
+func (db *msiDatabase) loadStrings() error {
+	pool, err := db.cf.stream("!_StringPool")
+	if err != nil {
+		return err
+	}
+
+	data, err := db.cf.stream("!_StringData")
+	if err != nil {
+		return err
+	}
+
+	if len(pool) < 4 {
+		return fmt.Errorf("Invalid MSI string pool")
+	}
+
+	word := func(i int) int {
+		return int(binary.LittleEndian.Uint16(pool[i*2:]))
+	}
+
+	// long string references are stored on 3 bytes
+	db.refSize = 2
+	if word(1)&0x8000 != 0 {
+		db.refSize = 3
+	}
+
+	db.strings = []string{""}
+	count := len(pool) / 4
+	offset := 0
+
+	for i := 1; i < count; {
+		length := word(i * 2)
+		refs := word(i*2 + 1)
+
+		switch {
+		// empty entries still have a string identifier
+		case length == 0 && refs == 0:
+			db.strings = append(db.strings, "")
+			i++
+			continue
+
+		// strings over 64k have their length stored in the next entry
+		case length == 0:
+			if i+1 >= count {
+				return fmt.Errorf("Invalid MSI string pool")
+			}
+			length = word(i*2+3)<<16 | word(i*2+2)
+			i += 2
+
+		default:
+			i++
+		}
+
+		if offset+length > len(data) {
+			return fmt.Errorf("Invalid MSI string data")
+		}
+
+		db.strings = append(db.strings, string(data[offset:offset+length]))
+		offset += length
+	}
+
+	return nil
+}
+
+// properties reads the Property table of the database, made of two string columns
+func (db *msiDatabase) properties() (map[string]string, error) {
+	table, err := db.cf.stream("!Property")
+	if err != nil {
+		return nil, err
+	}
+
+	if table == nil {
+		return nil, fmt.Errorf("MSI database has no Property table")
+	}
+
+	ref := func(b []byte) string {
+		i := int(b[0]) | int(b[1])<<8
+		if db.refSize == 3 {
+			i |= int(b[2]) << 16
+		}
+		if i < len(db.strings) {
+			return db.strings[i]
+		}
+		return ""
+	}
+
+	// the tables are stored column by column
+	rows := len(table) / (2 * db.refSize)
+	res := map[string]string{}
+	for i := 0; i < rows; i++ {
+		name := table[i*db.refSize:]
+		value := table[(rows+i)*db.refSize:]
+		res[ref(name)] = ref(value)
+	}
+
+	return res, nil
+}
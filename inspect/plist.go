@@ -0,0 +1,354 @@
+package inspect
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// ParsePlist decodes an XML or binary property list. Dictionaries are decoded as
+// map[string]interface{}, arrays as []interface{}, integers as int64, reals as float64, data as
+// []byte and dates as string
+func ParsePlist(data []byte) (interface{}, error) {
+	if bytes.HasPrefix(data, []byte("bplist00")) {
+		return parseBinaryPlist(data)
+	}
+
+	return parseXMLPlist(data)
+}
+
+// parsePlistDict decodes a property list whose root object is a dictionary
+func parsePlistDict(data []byte) (map[string]interface{}, error) {
+	v, err := ParsePlist(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dict, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("The property list root object is not a dictionary")
+	}
+
+	return dict, nil
+}
+
+// plistString returns the string value of the key of the dictionary, or an empty string
+func plistString(dict map[string]interface{}, key string) string {
+	switch v := dict[key].(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	}
+
+	return ""
+}
+
+func parseXMLPlist(data []byte) (interface{}, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+
+	for {
+		t, err := d.Token()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid XML property list: %v", err)
+		}
+
+		if se, ok := t.(xml.StartElement); ok && se.Name.Local != "plist" {
+			return decodeXMLPlistValue(d, se)
+		}
+	}
+}
+
+func decodeXMLPlistValue(d *xml.Decoder, se xml.StartElement) (interface{}, error) {
+	switch se.Name.Local {
+	case "dict":
+		dict := map[string]interface{}{}
+		for {
+			key, end, err := nextXMLPlistElement(d)
+			if err != nil || end {
+				return dict, err
+			}
+
+			if key.Name.Local != "key" {
+				return nil, fmt.Errorf("Invalid XML property list: expected a key, got '%v'", key.Name.Local)
+			}
+
+			var name string
+			if err := d.DecodeElement(&name, &key); err != nil {
+				return nil, err
+			}
+
+			value, end, err := nextXMLPlistElement(d)
+			if err != nil {
+				return nil, err
+			}
+			if end {
+				return nil, fmt.Errorf("Invalid XML property list: missing value of key '%v'", name)
+			}
+
+			if dict[name], err = decodeXMLPlistValue(d, value); err != nil {
+				return nil, err
+			}
+		}
+
+	case "array":
+		array := []interface{}{}
+		for {
+			item, end, err := nextXMLPlistElement(d)
+			if err != nil || end {
+				return array, err
+			}
+
+			v, err := decodeXMLPlistValue(d, item)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, v)
+		}
+
+	case "true", "false":
+		return se.Name.Local == "true", d.Skip()
+	}
+
+	var text string
+	if err := d.DecodeElement(&text, &se); err != nil {
+		return nil, err
+	}
+
+	switch se.Name.Local {
+	case "integer":
+		return strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	case "real":
+		return strconv.ParseFloat(strings.TrimSpace(text), 64)
+	case "data":
+		return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+	default:
+		return text, nil
+	}
+}
+
+// nextXMLPlistElement returns the next child element, or end=true when the parent element ends
+func nextXMLPlistElement(d *xml.Decoder) (xml.StartElement, bool, error) {
+	for {
+		t, err := d.Token()
+		if err != nil {
+			return xml.StartElement{}, false, err
+		}
+
+		switch e := t.(type) {
+		case xml.StartElement:
+			return e, false, nil
+		case xml.EndElement:
+			return xml.StartElement{}, true, nil
+		}
+	}
+}
+
+// binaryPlist decodes the objects of a "bplist00" property list
+type binaryPlist struct {
+	data       []byte
+	offsets    []uint64
+	objRefSize int
+	depth      int
+}
+
+func parseBinaryPlist(data []byte) (interface{}, error) {
+	if len(data) < 40 {
+		return nil, fmt.Errorf("Invalid binary property list: too short")
+	}
+
+	trailer := data[len(data)-32:]
+	offsetSize := int(trailer[6])
+	objRefSize := int(trailer[7])
+	numObjects := binary.BigEndian.Uint64(trailer[8:])
+	topObject := binary.BigEndian.Uint64(trailer[16:])
+	tableOffset := binary.BigEndian.Uint64(trailer[24:])
+
+	if offsetSize == 0 || offsetSize > 8 || objRefSize == 0 || objRefSize > 8 ||
+		numObjects > uint64(len(data)) || tableOffset > uint64(len(data)) ||
+		numObjects*uint64(offsetSize) > uint64(len(data))-tableOffset {
+		return nil, fmt.Errorf("Invalid binary property list: corrupted trailer")
+	}
+
+	p := binaryPlist{data: data, objRefSize: objRefSize}
+	for i := uint64(0); i < numObjects; i++ {
+		start := tableOffset + i*uint64(offsetSize)
+		p.offsets = append(p.offsets, readUint(data[start:start+uint64(offsetSize)]))
+	}
+
+	return p.object(topObject)
+}
+
+func readUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+func (p *binaryPlist) object(ref uint64) (interface{}, error) {
+	if ref >= uint64(len(p.offsets)) || p.offsets[ref] >= uint64(len(p.data)) {
+		return nil, fmt.Errorf("Invalid binary property list: object %d out of bounds", ref)
+	}
+
+	// protection against reference cycles
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > 64 {
+		return nil, fmt.Errorf("Invalid binary property list: too deep")
+	}
+
+	off := p.offsets[ref]
+	marker := p.data[off]
+	kind, info := marker>>4, int(marker&0x0f)
+	off++
+
+	switch kind {
+	case 0x0:
+		switch info {
+		case 0x8:
+			return false, nil
+		case 0x9:
+			return true, nil
+		}
+		return nil, nil
+
+	case 0x1:
+		b, err := p.slice(off, 1<<uint(info))
+		if err != nil {
+			return nil, err
+		}
+		return int64(readUint(b)), nil
+
+	case 0x2:
+		b, err := p.slice(off, 1<<uint(info))
+		if err != nil {
+			return nil, err
+		}
+		if len(b) == 4 {
+			return float64(math.Float32frombits(uint32(readUint(b)))), nil
+		}
+		return math.Float64frombits(readUint(b)), nil
+
+	case 0x3:
+		b, err := p.slice(off, 8)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.FormatFloat(math.Float64frombits(readUint(b)), 'f', -1, 64), nil
+	}
+
+	count, off, err := p.count(info, off)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case 0x4:
+		return p.slice(off, count)
+
+	case 0x5:
+		b, err := p.slice(off, count)
+		return string(b), err
+
+	case 0x6:
+		b, err := p.slice(off, count*2)
+		if err != nil {
+			return nil, err
+		}
+		u := make([]uint16, count)
+		for i := range u {
+			u[i] = binary.BigEndian.Uint16(b[i*2:])
+		}
+		return string(utf16.Decode(u)), nil
+
+	case 0xA:
+		refs, err := p.refs(off, count)
+		if err != nil {
+			return nil, err
+		}
+		array := make([]interface{}, 0, count)
+		for _, r := range refs {
+			v, err := p.object(r)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, v)
+		}
+		return array, nil
+
+	case 0xD:
+		refs, err := p.refs(off, count*2)
+		if err != nil {
+			return nil, err
+		}
+		dict := map[string]interface{}{}
+		for i := 0; i < count; i++ {
+			k, err := p.object(refs[i])
+			if err != nil {
+				return nil, err
+			}
+			v, err := p.object(refs[count+i])
+			if err != nil {
+				return nil, err
+			}
+			dict[fmt.Sprintf("%v", k)] = v
+		}
+		return dict, nil
+	}
+
+	return nil, fmt.Errorf("Invalid binary property list: unsupported object type 0x%x", kind)
+}
+
+// count returns the object count, stored in the marker or as an integer object following it
+func (p *binaryPlist) count(info int, off uint64) (int, uint64, error) {
+	if info != 0xf {
+		return info, off, nil
+	}
+
+	if off >= uint64(len(p.data)) || p.data[off]>>4 != 0x1 {
+		return 0, off, fmt.Errorf("Invalid binary property list: invalid object count")
+	}
+
+	size := 1 << uint(p.data[off]&0x0f)
+	b, err := p.slice(off+1, size)
+	if err != nil {
+		return 0, off, err
+	}
+
+	count := readUint(b)
+	if count > uint64(len(p.data)) {
+		return 0, off, fmt.Errorf("Invalid binary property list: invalid object count")
+	}
+
+	return int(count), off + 1 + uint64(size), nil
+}
+
+func (p *binaryPlist) refs(off uint64, count int) ([]uint64, error) {
+	b, err := p.slice(off, count*p.objRefSize)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]uint64, count)
+	for i := range refs {
+		refs[i] = readUint(b[i*p.objRefSize : (i+1)*p.objRefSize])
+	}
+	return refs, nil
+}
+
+func (p *binaryPlist) slice(off uint64, size int) ([]byte, error) {
+	if size < 0 || off+uint64(size) > uint64(len(p.data)) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return p.data[off : off+uint64(size)], nil
+}
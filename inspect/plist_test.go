@@ -0,0 +1,80 @@
+package inspect
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const xmlPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>com.test.app</string>
+	<key>CFBundleShortVersionString</key>
+	<string>1.2.3</string>
+	<key>CFBundleVersion</key>
+	<string>45</string>
+	<key>UIDeviceFamily</key>
+	<array>
+		<integer>1</integer>
+		<integer>2</integer>
+	</array>
+	<key>Enabled</key>
+	<true/>
+	<key>Name</key>
+	<string>Café ☃</string>
+</dict>
+</plist>`
+
+// binaryPlist is the binary version of xmlPlist, as written by Python plistlib
+const binaryPlistHex = "62706c6973743030d60102030405060708090a0b0c5f1012434642756e646c654964656e74696669" +
+	"65725f101a434642756e646c6553686f727456657273696f6e537472696e675f100f434642756e646c655665727369" +
+	"6f6e57456e61626c6564544e616d655e554944657669636546616d696c795c636f6d2e746573742e61707055312e32" +
+	"2e33523435096600430061006600e900202603a20d0e1001100208152a475961667582888b8c999c9e00000000000001" +
+	"01000000000000000f000000000000000000000000000000a0"
+
+func TestParsePlist(t *testing.T) {
+	binaryPlist, err := hex.DecodeString(binaryPlistHex)
+	assert.NoError(t, err)
+
+	for name, data := range map[string][]byte{
+		"XML":    []byte(xmlPlist),
+		"Binary": binaryPlist,
+	} {
+		t.Run(name, func(t *testing.T) {
+			dict, err := parsePlistDict(data)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{
+				"CFBundleIdentifier":         "com.test.app",
+				"CFBundleShortVersionString": "1.2.3",
+				"CFBundleVersion":            "45",
+				"UIDeviceFamily":             []interface{}{int64(1), int64(2)},
+				"Enabled":                    true,
+				"Name":                       "Café ☃",
+			}, dict)
+		})
+	}
+
+	t.Run("Invalid property lists should be reported", func(t *testing.T) {
+		_, err := ParsePlist([]byte("bplist00 not really"))
+		assert.Error(t, err)
+
+		_, err = ParsePlist([]byte("<plist><dict><key>a</key>"))
+		assert.Error(t, err)
+
+		// the offset table ends past the data once the overflowing offset is added
+		overflow := make([]byte, 48)
+		copy(overflow, "bplist00")
+		trailer := overflow[16:]
+		trailer[6], trailer[7] = 1, 1
+		binary.BigEndian.PutUint64(trailer[8:], 40)
+		binary.BigEndian.PutUint64(trailer[24:], math.MaxUint64-39)
+		_, err = ParsePlist(overflow)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,210 @@
+package inspect
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// protoField is a decoded protobuf field: varint fields have their value in Varint, length
+// delimited fields in Bytes
+type protoField struct {
+	Number int
+	Varint uint64
+	Bytes  []byte
+}
+
+// decodeProto decodes the fields of a protobuf message, without any schema
+func decodeProto(b []byte) ([]protoField, error) {
+	var fields []protoField
+
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, fmt.Errorf("Invalid protobuf message")
+		}
+		b = b[n:]
+
+		f := protoField{Number: int(key >> 3)}
+		switch key & 0x7 {
+		case 0:
+			f.Varint, n = binary.Uvarint(b)
+			if n <= 0 {
+				return nil, fmt.Errorf("Invalid protobuf varint")
+			}
+			b = b[n:]
+
+		case 1:
+			if len(b) < 8 {
+				return nil, fmt.Errorf("Invalid protobuf fixed64")
+			}
+			f.Varint = binary.LittleEndian.Uint64(b)
+			b = b[8:]
+
+		case 2:
+			size, n := binary.Uvarint(b)
+			if n <= 0 || size > uint64(len(b)-n) {
+				return nil, fmt.Errorf("Invalid protobuf length delimited field")
+			}
+			f.Bytes = b[n : n+int(size)]
+			b = b[n+int(size):]
+
+		case 5:
+			if len(b) < 4 {
+				return nil, fmt.Errorf("Invalid protobuf fixed32")
+			}
+			f.Varint = uint64(binary.LittleEndian.Uint32(b))
+			b = b[4:]
+
+		default:
+			return nil, fmt.Errorf("Unsupported protobuf wire type %d", key&0x7)
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+// parseProtoXML decodes an XML document compiled by aapt2 in the protobuf format (as found in
+// Android App Bundles) into an element tree. See the XmlNode message of aapt2 Resources.proto
+func parseProtoXML(data []byte) (*element, error) {
+	fields, err := decodeProto(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fields {
+		// XmlNode.element
+		if f.Number == 1 {
+			return parseProtoXMLElement(f.Bytes, 0)
+		}
+	}
+
+	return nil, fmt.Errorf("Protobuf XML document has no element")
+}
+
+func parseProtoXMLElement(data []byte, depth int) (*element, error) {
+	if depth > 64 {
+		return nil, fmt.Errorf("Protobuf XML document is too deep")
+	}
+
+	fields, err := decodeProto(data)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &element{Attrs: map[string]string{}}
+	for _, f := range fields {
+		switch f.Number {
+		// XmlElement.name
+		case 3:
+			e.Name = string(f.Bytes)
+
+		// XmlElement.attribute
+		case 4:
+			name, value, err := parseProtoXMLAttribute(f.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			e.Attrs[name] = value
+
+		// XmlElement.child, of type XmlNode
+		case 5:
+			node, err := decodeProto(f.Bytes)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, n := range node {
+				if n.Number == 1 {
+					c, err := parseProtoXMLElement(n.Bytes, depth+1)
+					if err != nil {
+						return nil, err
+					}
+					e.Children = append(e.Children, c)
+				}
+			}
+		}
+	}
+
+	return e, nil
+}
+
+func parseProtoXMLAttribute(data []byte) (string, string, error) {
+	fields, err := decodeProto(data)
+	if err != nil {
+		return "", "", err
+	}
+
+	var (
+		name     string
+		value    string
+		compiled []byte
+	)
+
+	for _, f := range fields {
+		switch f.Number {
+		// XmlAttribute.name
+		case 2:
+			name = string(f.Bytes)
+		// XmlAttribute.value
+		case 3:
+			value = string(f.Bytes)
+		// XmlAttribute.resource_id
+		case 5:
+			if n, ok := androidAttributes[uint32(f.Varint)]; ok && name == "" {
+				name = n
+			}
+		// XmlAttribute.compiled_item
+		case 6:
+			compiled = f.Bytes
+		}
+	}
+
+	if value == "" && compiled != nil {
+		value = protoItemValue(compiled)
+	}
+
+	return name, value, nil
+}
+
+// protoItemValue returns the textual value of a primitive compiled Item
+func protoItemValue(data []byte) string {
+	fields, err := decodeProto(data)
+	if err != nil {
+		return ""
+	}
+
+	for _, f := range fields {
+		// Item.prim
+		if f.Number != 7 {
+			continue
+		}
+
+		prim, err := decodeProto(f.Bytes)
+		if err != nil {
+			return ""
+		}
+
+		for _, p := range prim {
+			switch p.Number {
+			// Primitive.float_value
+			case 3:
+				return strconv.FormatFloat(float64(math.Float32frombits(uint32(p.Varint))), 'f', -1, 32)
+			// Primitive.int_decimal_value
+			case 6:
+				return strconv.FormatInt(int64(int32(p.Varint)), 10)
+			// Primitive.int_hexadecimal_value
+			case 7:
+				return fmt.Sprintf("0x%x", uint32(p.Varint))
+			// Primitive.boolean_value
+			case 8:
+				return strconv.FormatBool(p.Varint != 0)
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,165 @@
+package inspect
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"fmt"
+	"time"
+)
+
+const (
+	// ProfileDevelopment development profile, installable on the provisioned devices
+	ProfileDevelopment = "development"
+
+	// ProfileAdHoc ad-hoc distribution profile, installable on the provisioned devices
+	ProfileAdHoc = "adhoc"
+
+	// ProfileEnterprise in-house distribution profile, installable on any device
+	ProfileEnterprise = "enterprise"
+
+	// ProfileAppStore App Store distribution profile, only installable through the App Store
+	// and TestFlight
+	ProfileAppStore = "app-store"
+)
+
+// oidSignedData is the content type of the CMS envelope of the provisioning profiles
+var oidSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+// ProvisioningProfile is the description of the provisioning profile embedded in an IPA
+type ProvisioningProfile struct {
+	Name           string    `json:"name"`
+	UUID           string    `json:"uuid"`
+	Type           string    `json:"type"`
+	TeamID         string    `json:"team_id,omitempty"`
+	TeamName       string    `json:"team_name,omitempty"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	Devices        []string  `json:"devices,omitempty"`
+
+	// Entitlements granted by the profile
+	Entitlements map[string]interface{} `json:"-"`
+}
+
+// ExpiresWithin returns true if the profile is expired or expires within the provided duration
+func (p ProvisioningProfile) ExpiresWithin(d time.Duration, now time.Time) bool {
+	return !p.ExpirationDate.After(now.Add(d))
+}
+
+// cmsContentInfo is the ContentInfo CMS structure (RFC 5652)
+type cmsContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+// cmsSignedData is the beginning of the SignedData CMS structure, up to the certificates
+type cmsSignedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	EncapContentInfo struct {
+		EContentType asn1.ObjectIdentifier
+		EContent     []byte `asn1:"explicit,optional,tag:0"`
+	}
+	// Certificates is the optional [0] IMPLICIT certificate set, or the next element
+	Certificates asn1.RawValue `asn1:"optional"`
+}
+
+// ParseProvisioningProfile reads a provisioning profile (.mobileprovision): a property list
+// signed in a CMS envelope
+func ParseProvisioningProfile(data []byte) (*ProvisioningProfile, error) {
+	content, err := cmsContent(data)
+	if err != nil {
+		// BER encoded envelopes can not be decoded, the property list is looked up in the raw data
+		content, err = embeddedPlist(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plist, err := parsePlistDict(content)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ProvisioningProfile{
+		Name:     plistString(plist, "Name"),
+		UUID:     plistString(plist, "UUID"),
+		TeamName: plistString(plist, "TeamName"),
+	}
+
+	if teams, ok := plist["TeamIdentifier"].([]interface{}); ok && len(teams) > 0 {
+		p.TeamID, _ = teams[0].(string)
+	}
+
+	if s := plistString(plist, "ExpirationDate"); s != "" {
+		if p.ExpirationDate, err = time.Parse(time.RFC3339, s); err != nil {
+			return nil, fmt.Errorf("Invalid provisioning profile expiration date '%v'", s)
+		}
+	}
+
+	devices, _ := plist["ProvisionedDevices"].([]interface{})
+	for _, d := range devices {
+		if s, ok := d.(string); ok {
+			p.Devices = append(p.Devices, s)
+		}
+	}
+
+	p.Entitlements, _ = plist["Entitlements"].(map[string]interface{})
+	getTaskAllow, _ := p.Entitlements["get-task-allow"].(bool)
+	allDevices, _ := plist["ProvisionsAllDevices"].(bool)
+
+	switch {
+	case allDevices:
+		p.Type = ProfileEnterprise
+	case len(p.Devices) > 0 && getTaskAllow:
+		p.Type = ProfileDevelopment
+	case len(p.Devices) > 0:
+		p.Type = ProfileAdHoc
+	default:
+		p.Type = ProfileAppStore
+	}
+
+	return p, nil
+}
+
+// cmsSignedDataOf decodes a DER encoded CMS SignedData envelope
+func cmsSignedDataOf(data []byte) (*cmsSignedData, error) {
+	var ci cmsContentInfo
+	if _, err := asn1.Unmarshal(data, &ci); err != nil {
+		return nil, err
+	}
+
+	if !ci.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("Unexpected CMS content type %v", ci.ContentType)
+	}
+
+	var sd cmsSignedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, err
+	}
+
+	return &sd, nil
+}
+
+// cmsContent returns the signed content of a DER encoded CMS SignedData envelope
+func cmsContent(data []byte) ([]byte, error) {
+	sd, err := cmsSignedDataOf(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sd.EncapContentInfo.EContent) == 0 {
+		return nil, fmt.Errorf("No signed content in the CMS envelope")
+	}
+
+	return sd.EncapContentInfo.EContent, nil
+}
+
+// embeddedPlist returns the XML property list found in the provided data
+func embeddedPlist(data []byte) ([]byte, error) {
+	start := bytes.Index(data, []byte("<?xml"))
+	end := bytes.LastIndex(data, []byte("</plist>"))
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("No property list found in the provisioning profile")
+	}
+
+	return data[start : end+len("</plist>")], nil
+}
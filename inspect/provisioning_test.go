@@ -0,0 +1,53 @@
+package inspect
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProvisioningProfile(t *testing.T) {
+	t.Run("Profiles are read from the CMS envelope", func(t *testing.T) {
+		p, err := ParseProvisioningProfile(encodeCMS(t, []byte(testProfile), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, testProvisioningProfile, p)
+	})
+
+	t.Run("Profiles are looked up in envelopes which can not be decoded", func(t *testing.T) {
+		p, err := ParseProvisioningProfile([]byte("\x30\x80" + testProfile + "\x00\x00"))
+		assert.NoError(t, err)
+		assert.Equal(t, testProvisioningProfile, p)
+
+		_, err = ParseProvisioningProfile([]byte("\x30\x80\x00\x00"))
+		assert.EqualError(t, err, "No property list found in the provisioning profile")
+	})
+
+	t.Run("Profile types", func(t *testing.T) {
+		noDebug := strings.Replace(testProfile, "<true/>", "<false/>", 1)
+		noDevices := strings.Replace(noDebug, "<key>ProvisionedDevices</key>", "<key>Devices</key>", 1)
+		allDevices := strings.Replace(noDevices, "<key>Name</key>",
+			"<key>ProvisionsAllDevices</key><true/><key>Name</key>", 1)
+
+		testCases := map[string]string{
+			testProfile: ProfileDevelopment,
+			noDebug:     ProfileAdHoc,
+			noDevices:   ProfileAppStore,
+			allDevices:  ProfileEnterprise,
+		}
+
+		for profile, expected := range testCases {
+			p, err := ParseProvisioningProfile([]byte(profile))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, p.Type)
+		}
+	})
+
+	t.Run("Expiration", func(t *testing.T) {
+		now := time.Date(2029, 12, 1, 0, 0, 0, 0, time.UTC)
+		assert.False(t, testProvisioningProfile.ExpiresWithin(24*time.Hour, now))
+		assert.True(t, testProvisioningProfile.ExpiresWithin(60*24*time.Hour, now))
+		assert.True(t, testProvisioningProfile.ExpiresWithin(0, now.AddDate(1, 0, 0)))
+	})
+}
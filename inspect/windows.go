@@ -0,0 +1,72 @@
+package inspect
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type appxManifest struct {
+	Identity struct {
+		Name      string `xml:"Name,attr"`
+		Publisher string `xml:"Publisher,attr"`
+		Version   string `xml:"Version,attr"`
+	} `xml:"Identity"`
+	Dependencies struct {
+		TargetDeviceFamily []struct {
+			MinVersion string `xml:"MinVersion,attr"`
+		} `xml:"TargetDeviceFamily"`
+	} `xml:"Dependencies"`
+}
+
+// inspectAppx reads the manifest of APPX and MSIX packages and bundles
+func inspectAppx(a *archive) (*Info, error) {
+	data, _, err := a.readFile(func(name string) bool {
+		return strings.EqualFold(name, "AppxManifest.xml") ||
+			strings.EqualFold(name, "AppxMetadata/AppxBundleManifest.xml")
+	})
+	if err == os.ErrNotExist {
+		return nil, fmt.Errorf("No AppxManifest.xml or AppxMetadata/AppxBundleManifest.xml found in the package")
+	} else if err != nil {
+		return nil, err
+	}
+
+	var m appxManifest
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil {
+		return nil, err
+	}
+
+	info := &Info{
+		Platform:     PlatformWindows,
+		Identifier:   m.Identity.Name,
+		BuildVersion: m.Identity.Version,
+		Signed:       a.has(zipFileNamed("AppxSignature.p7x")),
+	}
+
+	if f := m.Dependencies.TargetDeviceFamily; len(f) > 0 {
+		info.MinOS = f[0].MinVersion
+	}
+
+	return info, nil
+}
+
+func inspectMSI(f io.ReaderAt) (*Info, error) {
+	db, err := openMSIDatabase(f)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := db.properties()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Info{
+		Platform:     PlatformWindows,
+		Identifier:   props["ProductName"],
+		BuildVersion: props["ProductVersion"],
+	}, nil
+}
@@ -0,0 +1,290 @@
+// Package selfupdate checks the latest release of the command line and replaces the running
+// binary with it, once its checksum and signature are verified.
+//
+// The releases are served from a base URL with the layout:
+//
+//	<base>/latest.json                       {"version": "0.3.0", "commit": "...", "date": "..."}
+//	<base>/<version>/go-appcenter_<os>_<arch>[.exe]
+//	<base>/<version>/checksums.txt           sha256sum output of the binaries
+//	<base>/<version>/checksums.txt.sig       base64 ed25519 signature of checksums.txt
+package selfupdate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Release is a release of the command line
+type Release struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit,omitempty"`
+	Date    string `json:"date,omitempty"`
+}
+
+// Updater downloads the releases from the base URL
+type Updater struct {
+	// BaseURL the releases are served from
+	BaseURL string
+
+	// PublicKey verifies the signature of the checksums. Without it, the releases are refused
+	// unless Insecure is set.
+	PublicKey ed25519.PublicKey
+
+	// Insecure allows to install the releases without public key, only their checksum being
+	// verified
+	Insecure bool
+
+	// OS and Arch of the downloaded binary, the ones of the running binary by default
+	OS   string
+	Arch string
+
+	HTTPClient *http.Client
+}
+
+// New returns an updater of the binary of the running platform
+func New(baseURL string) *Updater {
+	return &Updater{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// ParsePublicKey decodes a base64 ed25519 public key
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("Invalid ed25519 public key")
+	}
+
+	return ed25519.PublicKey(key), nil
+}
+
+// BinaryName returns the name of the binary of the platform
+func (u *Updater) BinaryName() string {
+	name := fmt.Sprintf("go-appcenter_%v_%v", u.OS, u.Arch)
+	if u.OS == "windows" {
+		name += ".exe"
+	}
+
+	return name
+}
+
+// Latest returns the latest release
+func (u *Updater) Latest(ctx context.Context) (*Release, error) {
+	b, err := u.get(ctx, "latest.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var r Release
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("Invalid release description: %v", err)
+	}
+
+	if r.Version == "" {
+		return nil, fmt.Errorf("Invalid release description: missing version")
+	}
+
+	return &r, nil
+}
+
+// Download downloads the binary of the release, and verifies its checksum and the signature of
+// the checksums. The signature is only skipped without public key when Insecure is set.
+func (u *Updater) Download(ctx context.Context, r *Release) ([]byte, error) {
+	if len(u.PublicKey) == 0 && !u.Insecure {
+		return nil, fmt.Errorf("No public key to verify the signature of release %v", r.Version)
+	}
+
+	checksums, err := u.get(ctx, r.Version, "checksums.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(u.PublicKey) > 0 {
+		sig, err := u.get(ctx, r.Version, "checksums.txt.sig")
+		if err != nil {
+			return nil, err
+		}
+
+		sig, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil || !ed25519.Verify(u.PublicKey, checksums, sig) {
+			return nil, fmt.Errorf("Invalid signature of the checksums of release %v", r.Version)
+		}
+	}
+
+	name := u.BinaryName()
+	expected, err := checksum(checksums, name)
+	if err != nil {
+		return nil, err
+	}
+
+	bin, err := u.get(ctx, r.Version, name)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(bin)
+	if hex.EncodeToString(sum[:]) != expected {
+		return nil, fmt.Errorf("Checksum mismatch for %v", name)
+	}
+
+	return bin, nil
+}
+
+func (u *Updater) get(ctx context.Context, parts ...string) ([]byte, error) {
+	url := strings.Join(append([]string{u.BaseURL}, parts...), "/")
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := u.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to download %v: %v", url, resp.Status)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// checksum returns the checksum of the file from the sha256sum output
+func checksum(checksums []byte, name string) (string, error) {
+	s := bufio.NewScanner(bytes.NewReader(checksums))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+
+	return "", fmt.Errorf("No checksum for %v", name)
+}
+
+// rename is os.Rename, replaced by the tests
+var rename = os.Rename
+
+// Replace atomically replaces the binary at the path: the new binary is written next to it, then
+// renamed over it, so the path always holds a complete binary. Windows cannot overwrite a running
+// binary, which is moved aside first, and back if the new one cannot take its place.
+func Replace(path string, bin []byte) error {
+	return replace(path, bin, runtime.GOOS == "windows")
+}
+
+func replace(path string, bin []byte, moveAside bool) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".new")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	_, err = f.Write(bin)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp, fi.Mode().Perm()); err != nil {
+		return err
+	}
+
+	if !moveAside {
+		return rename(tmp, path)
+	}
+
+	old := path + ".old"
+	//nolint:errcheck
+	os.Remove(old)
+	if err := rename(path, old); err != nil {
+		return err
+	}
+
+	if err := rename(tmp, path); err != nil {
+		if rerr := rename(old, path); rerr != nil {
+			return fmt.Errorf("%v, and the binary could not be restored from %v: %v", err, old, rerr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Newer reports whether the version is newer than the current one. The versions are compared
+// number by number, ignoring a leading v and the pre-release suffixes; a current version which is
+// not numeric, as the "dev" builds, is always older.
+func Newer(version string, current string) bool {
+	v, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+
+	c, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+
+	for i := 0; i < len(v) || i < len(c); i++ {
+		var a, b int
+		if i < len(v) {
+			a = v[i]
+		}
+		if i < len(c) {
+			b = c[i]
+		}
+		if a != b {
+			return a > b
+		}
+	}
+
+	return false
+}
+
+func parseVersion(s string) ([]int, bool) {
+	s = strings.TrimPrefix(s, "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+
+	var v []int
+	for _, p := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		v = append(v, n)
+	}
+
+	return v, true
+}
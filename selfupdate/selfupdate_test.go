@@ -0,0 +1,217 @@
+package selfupdate
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer serves the release 0.3.0 with the checksum of bin, signed with the key, and the
+// served binary
+func newTestServer(bin []byte, served []byte, key ed25519.PrivateKey) *httptest.Server {
+	sum := sha256.Sum256(bin)
+	checksums := fmt.Sprintf("%v  go-appcenter_linux_amd64\nabcd  go-appcenter_darwin_amd64\n", hex.EncodeToString(sum[:]))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"version": "0.3.0", "commit": "abc123", "date": "2020-05-04T12:30:00Z"}`)
+	})
+	mux.HandleFunc("/0.3.0/checksums.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, checksums)
+	})
+	mux.HandleFunc("/0.3.0/checksums.txt.sig", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte(checksums))))
+	})
+	mux.HandleFunc("/0.3.0/go-appcenter_linux_amd64", func(w http.ResponseWriter, r *http.Request) {
+		//nolint:errcheck
+		w.Write(served)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func newTestUpdater(url string, goos string) *Updater {
+	u := New(url + "/")
+	u.OS = goos
+	u.Arch = "amd64"
+	return u
+}
+
+func TestUpdater(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	_, other, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	bin := []byte("new binary")
+
+	t.Run("Should download and verify the latest release", func(t *testing.T) {
+		s := newTestServer(bin, bin, priv)
+		defer s.Close()
+
+		u := newTestUpdater(s.URL, "linux")
+		u.PublicKey = pub
+
+		r, err := u.Latest(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, &Release{Version: "0.3.0", Commit: "abc123", Date: "2020-05-04T12:30:00Z"}, r)
+
+		b, err := u.Download(context.Background(), r)
+		assert.NoError(t, err)
+		assert.Equal(t, bin, b)
+	})
+
+	t.Run("Should reject an invalid signature", func(t *testing.T) {
+		s := newTestServer(bin, bin, other)
+		defer s.Close()
+
+		u := newTestUpdater(s.URL, "linux")
+		u.PublicKey = pub
+
+		_, err := u.Download(context.Background(), &Release{Version: "0.3.0"})
+		assert.EqualError(t, err, "Invalid signature of the checksums of release 0.3.0")
+	})
+
+	t.Run("Should refuse an unsigned update without public key", func(t *testing.T) {
+		s := newTestServer(bin, bin, other)
+		defer s.Close()
+
+		_, err := newTestUpdater(s.URL, "linux").Download(context.Background(), &Release{Version: "0.3.0"})
+		assert.EqualError(t, err, "No public key to verify the signature of release 0.3.0")
+	})
+
+	t.Run("Should only check the checksum when insecure", func(t *testing.T) {
+		s := newTestServer(bin, bin, other)
+		defer s.Close()
+
+		u := newTestUpdater(s.URL, "linux")
+		u.Insecure = true
+
+		b, err := u.Download(context.Background(), &Release{Version: "0.3.0"})
+		assert.NoError(t, err)
+		assert.Equal(t, bin, b)
+	})
+
+	t.Run("Should reject a checksum mismatch", func(t *testing.T) {
+		s := newTestServer([]byte("expected binary"), bin, priv)
+		defer s.Close()
+
+		u := newTestUpdater(s.URL, "linux")
+		u.PublicKey = pub
+
+		_, err := u.Download(context.Background(), &Release{Version: "0.3.0"})
+		assert.EqualError(t, err, "Checksum mismatch for go-appcenter_linux_amd64")
+	})
+
+	t.Run("Should fail without checksum for the platform", func(t *testing.T) {
+		s := newTestServer(bin, bin, priv)
+		defer s.Close()
+
+		u := newTestUpdater(s.URL, "windows")
+		u.PublicKey = pub
+
+		_, err := u.Download(context.Background(), &Release{Version: "0.3.0"})
+		assert.EqualError(t, err, "No checksum for go-appcenter_windows_amd64.exe")
+	})
+
+	t.Run("Should fail on missing release", func(t *testing.T) {
+		s := newTestServer(bin, bin, priv)
+		defer s.Close()
+
+		u := newTestUpdater(s.URL, "linux")
+		u.PublicKey = pub
+
+		_, err := u.Download(context.Background(), &Release{Version: "0.4.0"})
+		assert.Error(t, err)
+	})
+}
+
+func TestReplace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "selfupdate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "go-appcenter")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("old binary"), 0755))
+
+	assert.NoError(t, Replace(path, []byte("new binary")))
+
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "new binary", string(b))
+
+	fi, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), fi.Mode().Perm())
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	assert.Error(t, Replace(filepath.Join(dir, "missing"), []byte("new binary")))
+}
+
+func TestReplaceMovingAside(t *testing.T) {
+	dir, err := ioutil.TempDir("", "selfupdate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "go-appcenter.exe")
+	read := func(path string) string {
+		b, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		return string(b)
+	}
+
+	t.Run("The running binary should be moved aside", func(t *testing.T) {
+		assert.NoError(t, ioutil.WriteFile(path, []byte("old binary"), 0755))
+
+		assert.NoError(t, replace(path, []byte("new binary"), true))
+		assert.Equal(t, "new binary", read(path))
+		assert.Equal(t, "old binary", read(path+".old"))
+	})
+
+	t.Run("The running binary should be restored when the new one can not replace it", func(t *testing.T) {
+		assert.NoError(t, ioutil.WriteFile(path, []byte("old binary"), 0755))
+
+		defer func(r func(string, string) error) { rename = r }(rename)
+		rename = func(from string, to string) error {
+			if to == path && from != path+".old" {
+				return fmt.Errorf("access denied")
+			}
+			return os.Rename(from, to)
+		}
+
+		assert.EqualError(t, replace(path, []byte("new binary"), true), "access denied")
+		assert.Equal(t, "old binary", read(path))
+	})
+}
+
+func TestNewer(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		current string
+		newer   bool
+	}{
+		{"0.3.0", "0.2.0", true},
+		{"v0.10.0", "0.9.1", true},
+		{"0.3", "0.3.0", false},
+		{"0.3.0", "0.3.0-rc.1", false},
+		{"0.2.0", "0.3.0", false},
+		{"0.3.0", "dev", true},
+		{"latest", "0.3.0", false},
+	} {
+		assert.Equal(t, tc.newer, Newer(tc.version, tc.current), "%v > %v", tc.version, tc.current)
+	}
+}